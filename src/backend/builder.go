@@ -0,0 +1,713 @@
+// builder.go
+
+package backend
+
+import (
+    "fast-graphql/src/frontend"
+    "errors"
+    "fmt"
+    "sort"
+    "strings"
+)
+
+/**
+ * BuildSchema
+ * build executable Schema from SDL (schema definition language) text, e.g.
+ *
+ *     schema, err := BuildSchema(`
+ *         type Query { user(id: ID!): User }
+ *         type User { id: ID! name: String }
+ *     `, map[string]ResolveFunction{
+ *         "Query.user": resolveUser,
+ *     })
+ *
+ * resolvers are keyed by "Type.field", fields without resolver pick value from resolved data.
+ * Interface and Union types resolve their runtime Object type by "Type.__resolveType" resolver,
+ * which receives resolved data as Context and returns the Object type name (or *Object).
+ * Root types are taken from schema definition, or the types named Query, Mutation and Subscription.
+ * Every type reference must be defined in SDL or be a built-in scalar.
+ */
+func BuildSchema(sdl string, resolvers map[string]ResolveFunction) (Schema, error) {
+    document, err := frontend.Compile(sdl)
+    if err != nil {
+        return Schema{}, err
+    }
+    return BuildSchemaFromDocument(document, resolvers)
+}
+
+// build executable Schema from compiled SDL Document, see BuildSchema()
+func BuildSchemaFromDocument(document *frontend.Document, resolvers map[string]ResolveFunction) (Schema, error) {
+    builder := &schemaBuilder{
+        definitions   : make(map[string]frontend.Definition),
+        extensions    : make(map[string][]frontend.Definition),
+        types         : make(map[string]Type),
+        resolvers     : resolvers,
+        usedResolvers : make(map[string]bool),
+    }
+    if err := builder.collectDefinitions(document); err != nil {
+        return Schema{}, err
+    }
+    if err := builder.buildTypes(); err != nil {
+        return Schema{}, err
+    }
+    if err := builder.checkResolvers(); err != nil {
+        return Schema{}, err
+    }
+    if err := builder.checkDefaultValues(); err != nil {
+        return Schema{}, err
+    }
+    schemaTemplate, err := builder.getSchemaTemplate()
+    if err != nil {
+        return Schema{}, err
+    }
+    return NewSchema(schemaTemplate)
+}
+
+type schemaBuilder struct {
+    // type definitions in document order
+    typeNames              []string
+    definitions              map[string]frontend.Definition
+    extensions               map[string][]frontend.Definition
+    schemaDefinitions     []*frontend.OperationTypeDefinition
+    directiveDefinitions  []*frontend.DirectiveDefinition
+    // built types mapped by type name, created before filled so types can reference each other
+    types                    map[string]Type
+    resolvers                map[string]ResolveFunction
+    usedResolvers            map[string]bool
+}
+
+var builtInScalars = map[string]*Scalar{
+    Int.Name    : Int,
+    Float.Name  : Float,
+    String.Name : String,
+    Bool.Name   : Bool,
+    ID.Name     : ID,
+}
+
+// collect type definitions and extensions by type name, create empty named types
+func (builder *schemaBuilder) collectDefinitions(document *frontend.Document) error {
+    var hasSchemaDefinition bool
+    for _, definition := range document.Definitions {
+        var name *frontend.Name
+        var namedType Type
+        switch d := definition.(type) {
+        case *frontend.SchemaDefinition:
+            if hasSchemaDefinition {
+                return fmt.Errorf("BuildSchema(): must provide only one schema definition, at line %d, column %d.", d.Location.Line, d.Location.Column)
+            }
+            hasSchemaDefinition = true
+            builder.schemaDefinitions = append(builder.schemaDefinitions, d.OperationTypeDefinitions...)
+            continue
+        case *frontend.SchemaExtension:
+            builder.schemaDefinitions = append(builder.schemaDefinitions, d.OperationTypeDefinitions...)
+            continue
+        case *frontend.DirectiveDefinition:
+            builder.directiveDefinitions = append(builder.directiveDefinitions, d)
+            continue
+        case *frontend.ScalarTypeDefinition:
+            name, namedType = d.Name, &Scalar{}
+        case *frontend.ObjectTypeDefinition:
+            name, namedType = d.Name, &Object{}
+        case *frontend.InterfaceTypeDefinition:
+            name, namedType = d.Name, &Interface{}
+        case *frontend.UnionTypeDefinition:
+            name, namedType = d.Name, &Union{}
+        case *frontend.EnumTypeDefinition:
+            name, namedType = d.Name, &Enum{}
+        case *frontend.InputObjectTypeDefinition:
+            name, namedType = d.Name, &InputObject{}
+        case *frontend.ScalarTypeExtension:
+            name = d.Name
+        case *frontend.ObjectTypeExtension:
+            name = d.Name
+        case *frontend.InterfaceTypeExtension:
+            name = d.Name
+        case *frontend.UnionTypeExtension:
+            name = d.Name
+        case *frontend.EnumTypeExtension:
+            name = d.Name
+        case *frontend.InputObjectTypeExtension:
+            name = d.Name
+        default:
+            definitionName, location := getDefinitionLocation(definition)
+            return fmt.Errorf("BuildSchema(): %s is not a type system definition, at line %d, column %d.", definitionName, location.Line, location.Column)
+        }
+        // extension
+        if namedType == nil {
+            builder.extensions[name.Value] = append(builder.extensions[name.Value], definition)
+            continue
+        }
+        if strings.HasPrefix(name.Value, "__") {
+            return fmt.Errorf("BuildSchema(): name \"%s\" must not begin with \"__\", which is reserved by introspection, at line %d, column %d.", name.Value, name.Location.Line, name.Location.Column)
+        }
+        if _, ok := builder.definitions[name.Value]; ok || builtInScalars[name.Value] != nil {
+            return fmt.Errorf("BuildSchema(): there can be only one type named \"%s\", at line %d, column %d.", name.Value, name.Location.Line, name.Location.Column)
+        }
+        builder.typeNames = append(builder.typeNames, name.Value)
+        builder.definitions[name.Value] = definition
+        builder.types[name.Value] = namedType
+    }
+    return nil
+}
+
+// get built type by name, include built-in scalars
+func (builder *schemaBuilder) getNamedType(name string) Type {
+    if scalar, ok := builtInScalars[name]; ok {
+        return scalar
+    }
+    return builder.types[name]
+}
+
+// build schema type from type reference, e.g. "[User!]!" returns NonNull(List(NonNull(User)))
+func (builder *schemaBuilder) getType(typeReference frontend.Type) (Type, error) {
+    switch t := typeReference.(type) {
+    case *frontend.NamedType:
+        namedType := builder.getNamedType(t.Value)
+        if namedType == nil {
+            return nil, fmt.Errorf("BuildSchema(): unknown type \"%s\", at line %d, column %d.", t.Value, t.Location.Line, t.Location.Column)
+        }
+        return namedType, nil
+    case frontend.ListType:
+        if len(t.Type) == 0 {
+            return nil, errors.New("BuildSchema(): list type does not have item type.")
+        }
+        payload, err := builder.getType(t.Type[0])
+        if err != nil {
+            return nil, err
+        }
+        return NewList(payload), nil
+    case frontend.NonNullType:
+        ofType, err := builder.getType(t.Type)
+        if err != nil {
+            return nil, err
+        }
+        return NewNonNull(ofType), nil
+    }
+    return nil, errors.New("BuildSchema(): type is illegal.")
+}
+
+// build Object type by name, the named type must be an Object
+func (builder *schemaBuilder) getObjectType(name *frontend.NamedType, usage string) (*Object, error) {
+    namedType, err := builder.getType(name)
+    if err != nil {
+        return nil, err
+    }
+    object, ok := namedType.(*Object)
+    if !ok {
+        return nil, fmt.Errorf("BuildSchema(): %s type \"%s\" must be an Object type, at line %d, column %d.", usage, name.Value, name.Location.Line, name.Location.Column)
+    }
+    return object, nil
+}
+
+/**
+ * fill every collected type by it's definition and extensions. Object types are filled at last,
+ * since NewObject() checks the fields of implemented interfaces.
+ */
+func (builder *schemaBuilder) buildTypes() error {
+    // extensions must extend defined type of same kind
+    for typeName, extensions := range builder.extensions {
+        for _, extension := range extensions {
+            if !isExtensionOf(extension, builder.definitions[typeName]) {
+                _, location := getDefinitionLocation(extension)
+                return fmt.Errorf("BuildSchema(): cannot extend type \"%s\" because it is not defined or is a different kind, at line %d, column %d.", typeName, location.Line, location.Column)
+            }
+        }
+    }
+    for _, buildObjects := range []bool{false, true} {
+        for _, typeName := range builder.typeNames {
+            var err error
+            switch d := builder.definitions[typeName].(type) {
+            case *frontend.ScalarTypeDefinition:
+                if !buildObjects {
+                    err = builder.buildScalar(d)
+                }
+            case *frontend.InterfaceTypeDefinition:
+                if !buildObjects {
+                    err = builder.buildInterface(d)
+                }
+            case *frontend.UnionTypeDefinition:
+                if !buildObjects {
+                    err = builder.buildUnion(d)
+                }
+            case *frontend.EnumTypeDefinition:
+                if !buildObjects {
+                    err = builder.buildEnum(d)
+                }
+            case *frontend.InputObjectTypeDefinition:
+                if !buildObjects {
+                    err = builder.buildInputObject(d)
+                }
+            case *frontend.ObjectTypeDefinition:
+                if buildObjects {
+                    err = builder.buildObject(d)
+                }
+            }
+            if err != nil {
+                return err
+            }
+        }
+    }
+    return nil
+}
+
+// check extension kind matches definition kind
+func isExtensionOf(extension frontend.Definition, definition frontend.Definition) bool {
+    switch extension.(type) {
+    case *frontend.ScalarTypeExtension:
+        _, ok := definition.(*frontend.ScalarTypeDefinition)
+        return ok
+    case *frontend.ObjectTypeExtension:
+        _, ok := definition.(*frontend.ObjectTypeDefinition)
+        return ok
+    case *frontend.InterfaceTypeExtension:
+        _, ok := definition.(*frontend.InterfaceTypeDefinition)
+        return ok
+    case *frontend.UnionTypeExtension:
+        _, ok := definition.(*frontend.UnionTypeDefinition)
+        return ok
+    case *frontend.EnumTypeExtension:
+        _, ok := definition.(*frontend.EnumTypeDefinition)
+        return ok
+    case *frontend.InputObjectTypeExtension:
+        _, ok := definition.(*frontend.InputObjectTypeDefinition)
+        return ok
+    }
+    return false
+}
+
+func (builder *schemaBuilder) buildScalar(definition *frontend.ScalarTypeDefinition) error {
+    scalar := NewScalar(ScalarTemplate{
+        Name        : definition.Name.Value,
+        Description : definition.Description.Value,
+    })
+    *builder.types[definition.Name.Value].(*Scalar) = *scalar
+    return nil
+}
+
+func (builder *schemaBuilder) buildObject(definition *frontend.ObjectTypeDefinition) error {
+    typeName := definition.Name.Value
+    // copy before appending extension fields, appending to document slice may write into it's backing array
+    fieldDefinitions := append([]*frontend.FieldDefinition{}, definition.FieldsDefinition...)
+    interfaceNames   := []*frontend.NamedType{}
+    if definition.ImplementsInterfaces != nil {
+        interfaceNames = append(interfaceNames, definition.ImplementsInterfaces.NamedTypes...)
+    }
+    for _, extension := range builder.extensions[typeName] {
+        objectTypeExtension := extension.(*frontend.ObjectTypeExtension)
+        fieldDefinitions = append(fieldDefinitions, objectTypeExtension.FieldsDefinition...)
+        if objectTypeExtension.ImplementsInterfaces != nil {
+            interfaceNames = append(interfaceNames, objectTypeExtension.ImplementsInterfaces.NamedTypes...)
+        }
+    }
+    fields, err := builder.buildFields(typeName, fieldDefinitions, true)
+    if err != nil {
+        return err
+    }
+    var interfaces []*Interface
+    for _, interfaceName := range interfaceNames {
+        interfaceType, err := builder.getType(interfaceName)
+        if err != nil {
+            return err
+        }
+        objectInterface, ok := interfaceType.(*Interface)
+        if !ok {
+            return fmt.Errorf("BuildSchema(): type \"%s\" can only implement Interface types, \"%s\" is not an Interface, at line %d, column %d.", typeName, interfaceName.Value, interfaceName.Location.Line, interfaceName.Location.Column)
+        }
+        interfaces = append(interfaces, objectInterface)
+    }
+    object, err := NewObject(ObjectTemplate{
+        Name        : typeName,
+        Description : definition.Description.Value,
+        Fields      : fields,
+        Interfaces  : interfaces,
+    })
+    if err != nil {
+        return err
+    }
+    *builder.types[typeName].(*Object) = *object
+    return nil
+}
+
+func (builder *schemaBuilder) buildInterface(definition *frontend.InterfaceTypeDefinition) error {
+    typeName := definition.Name.Value
+    fieldDefinitions := append([]*frontend.FieldDefinition{}, definition.FieldsDefinition...)
+    for _, extension := range builder.extensions[typeName] {
+        fieldDefinitions = append(fieldDefinitions, extension.(*frontend.InterfaceTypeExtension).FieldsDefinition...)
+    }
+    fields, err := builder.buildFields(typeName, fieldDefinitions, false)
+    if err != nil {
+        return err
+    }
+    it, err := NewInterface(InterfaceTemplate{
+        Name        : typeName,
+        Description : definition.Description.Value,
+        Fields      : fields,
+        ResolveType : builder.getResolveTypeFunction(typeName),
+    })
+    if err != nil {
+        return err
+    }
+    *builder.types[typeName].(*Interface) = *it
+    return nil
+}
+
+func (builder *schemaBuilder) buildUnion(definition *frontend.UnionTypeDefinition) error {
+    typeName := definition.Name.Value
+    var memberNames []*frontend.NamedType
+    if definition.UnionMemberTypes != nil {
+        memberNames = append(memberNames, definition.UnionMemberTypes.NamedTypes...)
+    }
+    for _, extension := range builder.extensions[typeName] {
+        if unionMemberTypes := extension.(*frontend.UnionTypeExtension).UnionMemberTypes; unionMemberTypes != nil {
+            memberNames = append(memberNames, unionMemberTypes.NamedTypes...)
+        }
+    }
+    var types []*Object
+    for _, memberName := range memberNames {
+        object, err := builder.getObjectType(memberName, "union member")
+        if err != nil {
+            return err
+        }
+        types = append(types, object)
+    }
+    union, err := NewUnion(UnionTemplate{
+        Name        : typeName,
+        Description : definition.Description.Value,
+        Types       : types,
+        ResolveType : builder.getResolveTypeFunction(typeName),
+    })
+    if err != nil {
+        return err
+    }
+    *builder.types[typeName].(*Union) = *union
+    return nil
+}
+
+// enum values of SDL enum are their names
+func (builder *schemaBuilder) buildEnum(definition *frontend.EnumTypeDefinition) error {
+    typeName := definition.Name.Value
+    valueDefinitions := append([]*frontend.EnumValueDefinition{}, definition.EnumValuesDefinition...)
+    for _, extension := range builder.extensions[typeName] {
+        valueDefinitions = append(valueDefinitions, extension.(*frontend.EnumTypeExtension).EnumValuesDefinition...)
+    }
+    values := make(EnumValues, len(valueDefinitions))
+    for _, valueDefinition := range valueDefinitions {
+        name := valueDefinition.EnumValue.Value
+        if _, ok := values[name.Value]; ok {
+            return fmt.Errorf("BuildSchema(): enum value \"%s.%s\" can only be defined once, at line %d, column %d.", typeName, name.Value, name.Location.Line, name.Location.Column)
+        }
+        values[name.Value] = &EnumValue{
+            Description       : valueDefinition.Description.Value,
+            DeprecationReason : getDeprecationReason(valueDefinition.Directives),
+        }
+    }
+    enum, err := NewEnum(EnumTemplate{
+        Name        : typeName,
+        Description : definition.Description.Value,
+        Values      : values,
+    })
+    if err != nil {
+        return err
+    }
+    *builder.types[typeName].(*Enum) = *enum
+    return nil
+}
+
+func (builder *schemaBuilder) buildInputObject(definition *frontend.InputObjectTypeDefinition) error {
+    typeName := definition.Name.Value
+    fieldDefinitions := append([]*frontend.InputValueDefinition{}, definition.InputFieldsDefinition...)
+    for _, extension := range builder.extensions[typeName] {
+        fieldDefinitions = append(fieldDefinitions, extension.(*frontend.InputObjectTypeExtension).InputFieldsDefinition...)
+    }
+    fields := make(InputObjectFields, len(fieldDefinitions))
+    for _, fieldDefinition := range fieldDefinitions {
+        fieldName := fieldDefinition.Name.Value
+        if _, ok := fields[fieldName]; ok {
+            return fmt.Errorf("BuildSchema(): field \"%s.%s\" can only be defined once, at line %d, column %d.", typeName, fieldName, fieldDefinition.Location.Line, fieldDefinition.Location.Column)
+        }
+        fieldType, defaultValue, err := builder.buildInputValue(typeName+"."+fieldName, fieldDefinition)
+        if err != nil {
+            return err
+        }
+        fields[fieldName] = &InputObjectField{
+            Name         : fieldName,
+            Type         : fieldType,
+            Description  : fieldDefinition.Description.Value,
+            DefaultValue : defaultValue,
+        }
+    }
+    inputObject, err := NewInputObject(InputObjectTemplate{
+        Name        : typeName,
+        Description : definition.Description.Value,
+        Fields      : fields,
+    })
+    if err != nil {
+        return err
+    }
+    *builder.types[typeName].(*InputObject) = *inputObject
+    return nil
+}
+
+// build fields of Object or Interface, Object fields take "Type.field" resolver
+func (builder *schemaBuilder) buildFields(typeName string, fieldDefinitions []*frontend.FieldDefinition, withResolvers bool) (ObjectFields, error) {
+    fields := make(ObjectFields, len(fieldDefinitions))
+    for _, fieldDefinition := range fieldDefinitions {
+        fieldName := fieldDefinition.Name.Value
+        if _, ok := fields[fieldName]; ok {
+            return nil, fmt.Errorf("BuildSchema(): field \"%s.%s\" can only be defined once, at line %d, column %d.", typeName, fieldName, fieldDefinition.Location.Line, fieldDefinition.Location.Column)
+        }
+        fieldType, err := builder.getType(fieldDefinition.Type)
+        if err != nil {
+            return nil, err
+        }
+        if _, ok := getNamedType(fieldType).(*InputObject); ok {
+            return nil, fmt.Errorf("BuildSchema(): the type of \"%s.%s\" must be an output type but got \"%s\", at line %d, column %d.", typeName, fieldName, getFrontendNamedTypeName(fieldDefinition.Type), fieldDefinition.Location.Line, fieldDefinition.Location.Column)
+        }
+        arguments, err := builder.buildArguments(typeName+"."+fieldName, fieldDefinition.ArgumentsDefinition)
+        if err != nil {
+            return nil, err
+        }
+        objectField := &ObjectField{
+            Name              : fieldName,
+            Type              : fieldType,
+            Description       : fieldDefinition.Description.Value,
+            Arguments         : arguments,
+            DeprecationReason : getDeprecationReason(fieldDefinition.Directives),
+        }
+        if withResolvers {
+            objectField.ResolveFunction = builder.getResolver(typeName+"."+fieldName)
+        }
+        fields[fieldName] = objectField
+    }
+    return fields, nil
+}
+
+// build Arguments of field or directive, nil if no argument defined
+func (builder *schemaBuilder) buildArguments(coordinate string, argumentDefinitions []*frontend.InputValueDefinition) (*Arguments, error) {
+    if len(argumentDefinitions) == 0 {
+        return nil, nil
+    }
+    arguments := make(Arguments, len(argumentDefinitions))
+    for _, argumentDefinition := range argumentDefinitions {
+        argumentName := argumentDefinition.Name.Value
+        if _, ok := arguments[argumentName]; ok {
+            return nil, fmt.Errorf("BuildSchema(): argument \"%s(%s:)\" can only be defined once, at line %d, column %d.", coordinate, argumentName, argumentDefinition.Location.Line, argumentDefinition.Location.Column)
+        }
+        argumentType, defaultValue, err := builder.buildInputValue(coordinate+"("+argumentName+":)", argumentDefinition)
+        if err != nil {
+            return nil, err
+        }
+        arguments[argumentName] = &Argument{
+            Name         : argumentName,
+            Type         : argumentType,
+            Description  : argumentDefinition.Description.Value,
+            DefaultValue : defaultValue,
+        }
+    }
+    return &arguments, nil
+}
+
+// build type and default value (in variable value form) of argument or input field
+func (builder *schemaBuilder) buildInputValue(coordinate string, definition *frontend.InputValueDefinition) (Type, interface{}, error) {
+    location := definition.Location
+    inputType, err := builder.getType(definition.Type)
+    if err != nil {
+        return nil, nil, err
+    }
+    if !isInputType(inputType) {
+        return nil, nil, fmt.Errorf("BuildSchema(): the type of \"%s\" must be an input type but got \"%s\", at line %d, column %d.", coordinate, getFrontendNamedTypeName(definition.Type), location.Line, location.Column)
+    }
+    if definition.DefaultValue == nil {
+        return inputType, nil, nil
+    }
+    if len(getValueVariableUsages(definition.DefaultValue)) > 0 {
+        return nil, nil, fmt.Errorf("BuildSchema(): default value of \"%s\" must not contain variables, at line %d, column %d.", coordinate, location.Line, location.Column)
+    }
+    defaultValue, err := valueFromLiteral(&GlobalVariables{}, definition.DefaultValue)
+    if err != nil {
+        return nil, nil, err
+    }
+    // input types may be not filled yet, default values are checked by checkDefaultValues()
+    return inputType, defaultValue, nil
+}
+
+// get resolver by "Type.field" key and mark it used
+func (builder *schemaBuilder) getResolver(key string) ResolveFunction {
+    resolveFunction, ok := builder.resolvers[key]
+    if ok {
+        builder.usedResolvers[key] = true
+    }
+    return resolveFunction
+}
+
+// wrap "Type.__resolveType" resolver as ResolveTypeFunction, nil if not provided
+func (builder *schemaBuilder) getResolveTypeFunction(typeName string) ResolveTypeFunction {
+    resolveFunction := builder.getResolver(typeName+".__resolveType")
+    if resolveFunction == nil {
+        return nil
+    }
+    types := builder.types
+    return func(p ResolveTypeParams) *Object {
+        resolvedType, err := resolveFunction(ResolveParams{Context: p.Value})
+        if err != nil {
+            return nil
+        }
+        switch t := resolvedType.(type) {
+        case string:
+            object, _ := types[t].(*Object)
+            return object
+        case *Object:
+            return t
+        }
+        return nil
+    }
+}
+
+// every resolver must match an Object field or abstract type
+func (builder *schemaBuilder) checkResolvers() error {
+    var unusedKeys []string
+    for key := range builder.resolvers {
+        if !builder.usedResolvers[key] {
+            unusedKeys = append(unusedKeys, key)
+        }
+    }
+    if len(unusedKeys) > 0 {
+        sort.Strings(unusedKeys)
+        return errors.New("BuildSchema(): resolver \""+unusedKeys[0]+"\" does not match any Object field or abstract type.")
+    }
+    return nil
+}
+
+// coerce default values after all input types are filled
+func (builder *schemaBuilder) checkDefaultValues() error {
+    checkArguments := func(coordinate string, arguments *Arguments) error {
+        if arguments == nil {
+            return nil
+        }
+        for argumentName, argument := range *arguments {
+            if argument.DefaultValue == nil {
+                continue
+            }
+            if _, err := coerceVariableValue(argument.DefaultValue, argument.Type); err != nil {
+                return errors.New("BuildSchema(): invalid default value of \""+coordinate+"("+argumentName+":)\", "+err.Error())
+            }
+        }
+        return nil
+    }
+    for _, typeName := range builder.typeNames {
+        switch t := builder.types[typeName].(type) {
+        case *Object:
+            for fieldName, field := range t.Fields {
+                if err := checkArguments(typeName+"."+fieldName, field.Arguments); err != nil {
+                    return err
+                }
+            }
+        case *Interface:
+            for fieldName, field := range t.Fields {
+                if err := checkArguments(typeName+"."+fieldName, field.Arguments); err != nil {
+                    return err
+                }
+            }
+        case *InputObject:
+            for fieldName, field := range t.Fields {
+                if field.DefaultValue == nil {
+                    continue
+                }
+                if _, err := coerceVariableValue(field.DefaultValue, field.Type); err != nil {
+                    return errors.New("BuildSchema(): invalid default value of \""+typeName+"."+fieldName+"\", "+err.Error())
+                }
+            }
+        }
+    }
+    return nil
+}
+
+// build SchemaTemplate with root types, directives and all defined types
+func (builder *schemaBuilder) getSchemaTemplate() (SchemaTemplate, error) {
+    schemaTemplate := SchemaTemplate{}
+    rootTypes := map[string]*Object{}
+    if len(builder.schemaDefinitions) > 0 {
+        for _, operationTypeDefinition := range builder.schemaDefinitions {
+            operationTypeName := operationTypeDefinition.OperationTypeName
+            if _, ok := rootTypes[operationTypeName]; ok {
+                location := operationTypeDefinition.Location
+                return schemaTemplate, fmt.Errorf("BuildSchema(): there can be only one %s type in schema, at line %d, column %d.", operationTypeName, location.Line, location.Column)
+            }
+            object, err := builder.getObjectType(operationTypeDefinition.NamedType, operationTypeName)
+            if err != nil {
+                return schemaTemplate, err
+            }
+            rootTypes[operationTypeName] = object
+        }
+    } else {
+        // default root type names
+        for operationTypeName, typeName := range map[string]string{
+            frontend.OperationTypeQueryName        : "Query",
+            frontend.OperationTypeMutationName     : "Mutation",
+            frontend.OperationTypeSubscriptionName : "Subscription",
+        } {
+            if object, ok := builder.types[typeName].(*Object); ok {
+                rootTypes[operationTypeName] = object
+            }
+        }
+    }
+    schemaTemplate.Query        = rootTypes[frontend.OperationTypeQueryName]
+    schemaTemplate.Mutation     = rootTypes[frontend.OperationTypeMutationName]
+    schemaTemplate.Subscription = rootTypes[frontend.OperationTypeSubscriptionName]
+    if schemaTemplate.Query == nil {
+        return schemaTemplate, errors.New("BuildSchema(): query root type must be provided.")
+    }
+
+    for _, directiveDefinition := range builder.directiveDefinitions {
+        directive, err := builder.buildDirective(directiveDefinition)
+        if err != nil {
+            return schemaTemplate, err
+        }
+        schemaTemplate.Directives = append(schemaTemplate.Directives, directive)
+    }
+    // types not reachable from root types, e.g. Object implementations of Interface
+    for _, typeName := range builder.typeNames {
+        schemaTemplate.Types = append(schemaTemplate.Types, builder.types[typeName])
+    }
+    return schemaTemplate, nil
+}
+
+// SDL defined directive has no DirectiveFunction
+func (builder *schemaBuilder) buildDirective(definition *frontend.DirectiveDefinition) (*Directive, error) {
+    name := definition.Name
+    for _, location := range definition.DirectiveLocations {
+        var known bool
+        for _, directiveLocation := range directiveLocations {
+            known = known || location == directiveLocation
+        }
+        if !known {
+            return nil, fmt.Errorf("BuildSchema(): unknown directive location \"%s\" of @%s, at line %d, column %d.", location, name.Value, definition.Location.Line, definition.Location.Column)
+        }
+    }
+    arguments, err := builder.buildArguments("@"+name.Value, definition.ArgumentsDefinition)
+    if err != nil {
+        return nil, err
+    }
+    return NewDirective(DirectiveTemplate{
+        Name        : name.Value,
+        Description : definition.Description.Value,
+        Locations   : definition.DirectiveLocations,
+        Arguments   : arguments,
+    })
+}
+
+// get reason of @deprecated directive, empty if not deprecated
+func getDeprecationReason(directives []*frontend.Directive) string {
+    for _, directive := range directives {
+        if directive.Name.Value != DeprecatedDirective.Name {
+            continue
+        }
+        for _, argument := range directive.Arguments {
+            if reason, ok := argument.Value.(frontend.StringValue); ok && argument.Name.Value == "reason" {
+                return reason.Value
+            }
+        }
+        return DefaultDeprecationReason
+    }
+    return ""
+}
@@ -0,0 +1,82 @@
+// builder_test.go
+
+package backend
+
+import (
+    "fast-graphql/src/frontend"
+    "testing"
+)
+
+var builderTestSDL = `
+type Query implements Node { id: ID! a: Int b: Int }
+interface Node { id: ID! a: Int b: Int }
+enum E { A B C }
+input I { a: Int b: Int c: Int }
+extend type Query { c: Int }
+extend interface Node { c: Int }
+extend enum E { D }
+extend input I { d: Int }
+`
+
+// merging type extensions must not write into the slices of the compiled document
+func TestBuildSchemaFromDocumentKeepsDocument(t *testing.T) {
+    document, err := frontend.Compile(builderTestSDL)
+    if err != nil {
+        t.Fatalf("Compile() error: %v", err)
+    }
+    // AST built or rewritten by caller may have spare capacity, appending to it writes into the backing array
+    objectDefinition      := document.Definitions[0].(*frontend.ObjectTypeDefinition)
+    ifaceDefinition       := document.Definitions[1].(*frontend.InterfaceTypeDefinition)
+    enumDefinition        := document.Definitions[2].(*frontend.EnumTypeDefinition)
+    inputObjectDefinition := document.Definitions[3].(*frontend.InputObjectTypeDefinition)
+    objectDefinition.FieldsDefinition           = append(make([]*frontend.FieldDefinition, 0, 8), objectDefinition.FieldsDefinition...)
+    ifaceDefinition.FieldsDefinition            = append(make([]*frontend.FieldDefinition, 0, 8), ifaceDefinition.FieldsDefinition...)
+    enumDefinition.EnumValuesDefinition         = append(make([]*frontend.EnumValueDefinition, 0, 8), enumDefinition.EnumValuesDefinition...)
+    inputObjectDefinition.InputFieldsDefinition = append(make([]*frontend.InputValueDefinition, 0, 8), inputObjectDefinition.InputFieldsDefinition...)
+    object      := objectDefinition.FieldsDefinition
+    iface       := ifaceDefinition.FieldsDefinition
+    enum        := enumDefinition.EnumValuesDefinition
+    inputObject := inputObjectDefinition.InputFieldsDefinition
+    objectArray      := append([]*frontend.FieldDefinition{}, object[:cap(object)]...)
+    ifaceArray       := append([]*frontend.FieldDefinition{}, iface[:cap(iface)]...)
+    enumArray        := append([]*frontend.EnumValueDefinition{}, enum[:cap(enum)]...)
+    inputObjectArray := append([]*frontend.InputValueDefinition{}, inputObject[:cap(inputObject)]...)
+
+    schema, err := BuildSchemaFromDocument(document, map[string]ResolveFunction{
+        "Node.__resolveType": func(p ResolveParams) (interface{}, error) {
+            return "Query", nil
+        },
+    })
+    if err != nil {
+        t.Fatalf("BuildSchemaFromDocument() error: %v", err)
+    }
+    for i := range objectArray {
+        if object[:cap(object)][i] != objectArray[i] || iface[:cap(iface)][i] != ifaceArray[i] {
+            t.Errorf("fields definition %d of compiled document changed.", i)
+        }
+    }
+    for i := range enumArray {
+        if enum[:cap(enum)][i] != enumArray[i] {
+            t.Errorf("enum values definition %d of compiled document changed.", i)
+        }
+    }
+    for i := range inputObjectArray {
+        if inputObject[:cap(inputObject)][i] != inputObjectArray[i] {
+            t.Errorf("input fields definition %d of compiled document changed.", i)
+        }
+    }
+
+    // extensions are still merged into built types
+    if fields := schema.Query.Fields; len(fields) != 4 || fields["c"] == nil {
+        t.Errorf("Query fields = %v, want id, a, b and c.", fields)
+    }
+    if fields := schema.GetType("Node").(*Interface).Fields; len(fields) != 4 || fields["c"] == nil {
+        t.Errorf("Node fields = %v, want id, a, b and c.", fields)
+    }
+    if values := schema.GetType("E").(*Enum).Values; len(values) != 4 || values["D"] == nil {
+        t.Errorf("E values = %v, want A, B, C and D.", values)
+    }
+    if fields := schema.GetType("I").(*InputObject).Fields; len(fields) != 4 || fields["d"] == nil {
+        t.Errorf("I fields = %v, want a, b, c and d.", fields)
+    }
+}
@@ -0,0 +1,217 @@
+// directive.go
+package backend
+
+import (
+    "fast-graphql/src/frontend"
+    "errors"
+)
+
+/**
+ * Directive Definition
+ * Directives provide a way to describe alternate runtime execution and type validation behavior in a GraphQL document.
+ * The built-in @skip and @include directives are evaluated during CollectFields.
+ * User defined directives with a DirectiveFunction wrap the ResolveFunction of the Field they are placed on.
+ */
+
+// executable directive locations
+const (
+    DirectiveLocationQuery              = "QUERY"
+    DirectiveLocationMutation           = "MUTATION"
+    DirectiveLocationSubscription       = "SUBSCRIPTION"
+    DirectiveLocationField              = "FIELD"
+    DirectiveLocationFragmentDefinition = "FRAGMENT_DEFINITION"
+    DirectiveLocationFragmentSpread     = "FRAGMENT_SPREAD"
+    DirectiveLocationInlineFragment     = "INLINE_FRAGMENT"
+)
+
+// type system directive locations
+const (
+    DirectiveLocationVariableDefinition    = "VARIABLE_DEFINITION"
+    DirectiveLocationSchema                = "SCHEMA"
+    DirectiveLocationScalar                = "SCALAR"
+    DirectiveLocationObject                = "OBJECT"
+    DirectiveLocationFieldDefinition       = "FIELD_DEFINITION"
+    DirectiveLocationArgumentDefinition    = "ARGUMENT_DEFINITION"
+    DirectiveLocationInterface             = "INTERFACE"
+    DirectiveLocationUnion                 = "UNION"
+    DirectiveLocationEnum                  = "ENUM"
+    DirectiveLocationEnumValue             = "ENUM_VALUE"
+    DirectiveLocationInputObject           = "INPUT_OBJECT"
+    DirectiveLocationInputFieldDefinition  = "INPUT_FIELD_DEFINITION"
+)
+
+// resolved directive arguments, passed into DirectiveFunction
+type DirectiveParams struct {
+    Arguments map[string]interface{}
+}
+
+// wraps the ResolveFunction of a Field, returns the new ResolveFunction
+type DirectiveFunction func(p DirectiveParams, resolveFunction ResolveFunction) ResolveFunction
+
+type DirectiveTemplate struct {
+    Name              string
+    Description       string
+    Locations         []string
+    Arguments        *Arguments
+    DirectiveFunction DirectiveFunction
+}
+
+type Directive struct {
+    Name              string
+    Description       string
+    Locations         []string
+    Arguments        *Arguments
+    DirectiveFunction DirectiveFunction `json:"-"`
+}
+
+func NewDirective(directiveTemplate DirectiveTemplate) (*Directive, error) {
+    directive := &Directive{}
+
+    // check directive input
+    if directiveTemplate.Name == "" {
+        err := errors.New("DirectiveTemplate.Name is not defined")
+        return nil, err
+    }
+    if len(directiveTemplate.Locations) == 0 {
+        err := errors.New("DirectiveTemplate.Locations is not defined")
+        return nil, err
+    }
+
+    directive.Name = directiveTemplate.Name
+    directive.Description = directiveTemplate.Description
+    directive.Locations = directiveTemplate.Locations
+    directive.Arguments = directiveTemplate.Arguments
+    directive.DirectiveFunction = directiveTemplate.DirectiveFunction
+    return directive, nil
+}
+
+func (directive *Directive) GetName() string {
+    return directive.Name
+}
+
+func (directive *Directive) HasLocation(location string) bool {
+    for _, directiveLocation := range directive.Locations {
+        if directiveLocation == location {
+            return true
+        }
+    }
+    return false
+}
+
+// built-in directives
+var IncludeDirective = &Directive{
+    Name: "include",
+    Description: "Directs the executor to include this field or fragment only when the `if` argument is true.",
+    Locations: []string{
+        DirectiveLocationField,
+        DirectiveLocationFragmentSpread,
+        DirectiveLocationInlineFragment,
+    },
+    Arguments: &Arguments{
+        "if": &Argument{
+            Name: "if",
+            Type: NewNonNull(Bool),
+        },
+    },
+}
+
+var SkipDirective = &Directive{
+    Name: "skip",
+    Description: "Directs the executor to skip this field or fragment when the `if` argument is true.",
+    Locations: []string{
+        DirectiveLocationField,
+        DirectiveLocationFragmentSpread,
+        DirectiveLocationInlineFragment,
+    },
+    Arguments: &Arguments{
+        "if": &Argument{
+            Name: "if",
+            Type: NewNonNull(Bool),
+        },
+    },
+}
+
+// default reason of @deprecated without reason argument
+const DefaultDeprecationReason = "No longer supported"
+
+var DeprecatedDirective = &Directive{
+    Name: "deprecated",
+    Description: "Marks an element of a GraphQL schema as no longer supported.",
+    Locations: []string{
+        DirectiveLocationFieldDefinition,
+        DirectiveLocationEnumValue,
+    },
+    Arguments: &Arguments{
+        "reason": &Argument{
+            Name: "reason",
+            Type: String,
+            Description: "Explains why this element was deprecated, usually also including a suggestion for how to access supported similar data.",
+            DefaultValue: DefaultDeprecationReason,
+        },
+    },
+}
+
+var SpecifiedDirectives = []*Directive{IncludeDirective, SkipDirective, DeprecatedDirective}
+
+// get built-in directive by name, nil if not found
+func getSpecifiedDirective(name string) *Directive {
+    for _, directive := range SpecifiedDirectives {
+        if directive.Name == name {
+            return directive
+        }
+    }
+    return nil
+}
+
+// check @skip and @include directives of Field, FragmentSpread or InlineFragment
+func shouldIncludeNode(g *GlobalVariables, directives []*frontend.Directive) bool {
+    for _, directive := range directives {
+        switch directive.Name.Value {
+        case SkipDirective.Name:
+            if skip, ok := getDirectiveIfArgument(g, directive); ok && skip {
+                return false
+            }
+        case IncludeDirective.Name:
+            if include, ok := getDirectiveIfArgument(g, directive); ok && !include {
+                return false
+            }
+        }
+    }
+    return true
+}
+
+// get the "if" argument of @skip or @include, resolve variable from GlobalVariables.QueryVariablesMap
+func getDirectiveIfArgument(g *GlobalVariables, directive *frontend.Directive) (bool, bool) {
+    for _, argument := range directive.Arguments {
+        if argument.Name.Value != "if" {
+            continue
+        }
+        switch value := argument.Value.(type) {
+        case frontend.BooleanValue:
+            return value.Value, true
+        case frontend.Variable:
+            variableValue, _ := getVariableValue(g, value)
+            if matched, ok := variableValue.(bool); ok {
+                return matched, true
+            }
+        }
+    }
+    return false, false
+}
+
+// wrap ResolveFunction by Field directives which have DirectiveFunction, in order of appearance
+func applyDirectives(g *GlobalVariables, request Request, directives []*frontend.Directive, resolveFunction ResolveFunction) (ResolveFunction, error) {
+    for _, directive := range directives {
+        targetDirective := request.Schema.GetDirective(directive.Name.Value)
+        if targetDirective == nil || targetDirective.DirectiveFunction == nil {
+            continue
+        }
+        var directiveParams DirectiveParams
+        var err error
+        if directiveParams.Arguments, err = coerceArgumentValues(g, directive.Arguments, targetDirective.Arguments); err != nil {
+            return nil, newLocatedError(err, directive.Location)
+        }
+        resolveFunction = targetDirective.DirectiveFunction(directiveParams, resolveFunction)
+    }
+    return resolveFunction, nil
+}
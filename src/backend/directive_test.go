@@ -0,0 +1,112 @@
+// directive_test.go
+
+package backend
+
+import (
+    "strings"
+    "testing"
+)
+
+func newDirectiveTestQuery(t *testing.T) *Object {
+    t.Helper()
+    query, err := NewObject(ObjectTemplate{
+        Name: "Query",
+        Fields: ObjectFields{
+            "a": &ObjectField{
+                Name: "a",
+                Type: String,
+                ResolveFunction: func(p ResolveParams) (interface{}, error) {
+                    return "a", nil
+                },
+            },
+            "b": &ObjectField{
+                Name: "b",
+                Type: String,
+                ResolveFunction: func(p ResolveParams) (interface{}, error) {
+                    return "b", nil
+                },
+            },
+        },
+    })
+    if err != nil {
+        t.Fatalf("NewObject() error: %v", err)
+    }
+    return query
+}
+
+func TestExecuteSkipAndInclude(t *testing.T) {
+    schema := newExecuteTestSchema(t, SchemaTemplate{Query: newDirectiveTestQuery(t)})
+    runExecuteTests(t, schema, []executeTest{
+        {name: "skip field", query: `{ a @skip(if: true) b }`,
+            result: `{"data":{"b":"b"}}`},
+        {name: "include field", query: `{ a @include(if: false) b @include(if: true) }`,
+            result: `{"data":{"b":"b"}}`},
+        {name: "not skipped and included", query: `{ a @skip(if: false) @include(if: true) }`,
+            result: `{"data":{"a":"a"}}`},
+        {name: "skip wins over include", query: `{ a @skip(if: true) @include(if: true) b }`,
+            result: `{"data":{"b":"b"}}`},
+        {name: "same response key not skipped everywhere", query: `{ a @skip(if: true) a }`,
+            result: `{"data":{"a":"a"}}`},
+        {name: "fragment spread", query: `{ ...F @include(if: false) b } fragment F on Query { a }`,
+            result: `{"data":{"b":"b"}}`},
+        {name: "inline fragment", query: `{ ... @skip(if: true) { a } ... @skip(if: false) { b } }`,
+            result: `{"data":{"b":"b"}}`},
+        {name: "variable", query: `query Q($skip: Boolean!) { a @skip(if: $skip) b @include(if: $skip) }`, variables: `{"skip": true}`,
+            result: `{"data":{"b":"b"}}`},
+        {name: "variable default value", query: `query Q($skip: Boolean = true) { a @skip(if: $skip) b }`,
+            result: `{"data":{"b":"b"}}`},
+        {name: "missing if argument", query: `{ a @skip }`,
+            result: `{"data":null,"errors":[{"message":"Directive \"@skip\" argument \"if\" of type \"Boolean!\" is required, but it was not provided.","locations":[{"line":1,"column":5}]}]}`},
+        {name: "invalid if argument", query: `{ a @include(if: "x") }`,
+            result: `{"data":null,"errors":[{"message":"Expected value of type \"Boolean\", found \"x\".","locations":[{"line":1,"column":18}]}]}`},
+    })
+}
+
+func TestExecuteDirectiveFunction(t *testing.T) {
+    upper, _ := NewDirective(DirectiveTemplate{
+        Name:      "upper",
+        Locations: []string{DirectiveLocationField},
+        DirectiveFunction: func(p DirectiveParams, resolveFunction ResolveFunction) ResolveFunction {
+            return func(p ResolveParams) (interface{}, error) {
+                value, err := resolveFunction(p)
+                if s, ok := value.(string); ok {
+                    return strings.ToUpper(s), err
+                }
+                return value, err
+            }
+        },
+    })
+    prefix, _ := NewDirective(DirectiveTemplate{
+        Name:      "prefix",
+        Locations: []string{DirectiveLocationField},
+        Arguments: &Arguments{
+            "with": &Argument{Name: "with", Type: String, DefaultValue: "- "},
+        },
+        DirectiveFunction: func(directiveParams DirectiveParams, resolveFunction ResolveFunction) ResolveFunction {
+            return func(p ResolveParams) (interface{}, error) {
+                value, err := resolveFunction(p)
+                return directiveParams.Arguments["with"].(string) + value.(string), err
+            }
+        },
+    })
+    schema := newExecuteTestSchema(t, SchemaTemplate{
+        Query:      newDirectiveTestQuery(t),
+        Directives: []*Directive{upper, prefix},
+    })
+    runExecuteTests(t, schema, []executeTest{
+        {name: "wrap resolver", query: `{ a @upper b }`,
+            result: `{"data":{"a":"A","b":"b"}}`},
+        {name: "argument", query: `{ a @prefix(with: "x") }`,
+            result: `{"data":{"a":"xa"}}`},
+        {name: "argument default value", query: `{ a @prefix }`,
+            result: `{"data":{"a":"- a"}}`},
+        {name: "argument variable", query: `query Q($with: String) { a @prefix(with: $with) }`, variables: `{"with": "v"}`,
+            result: `{"data":{"a":"va"}}`},
+        {name: "wrapped in order of appearance", query: `{ a: a @upper @prefix(with: "x") b: a @prefix(with: "x") @upper }`,
+            result: `{"data":{"a":"xA","b":"XA"}}`},
+        {name: "only on the field it is placed on", query: `{ x: a @upper y: a }`,
+            result: `{"data":{"x":"A","y":"a"}}`},
+        {name: "with skip", query: `{ a @upper @skip(if: true) b @upper }`,
+            result: `{"data":{"b":"B"}}`},
+    })
+}
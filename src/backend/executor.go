@@ -8,15 +8,15 @@ import (
     "log"
     "reflect"
     "encoding/json"
+    "strings"
+    "sort"
+    "strconv"
+    "math"
 
-    // "strconv"
-    "os"
-    "github.com/davecgh/go-spew/spew"
+    "time"
 
 )
 
-const DUMP_FRONTEND = false
-
 type Request struct {
     // GraphQL Schema config for server side
     Schema Schema 
@@ -26,49 +26,131 @@ type Request struct {
 
     // GraphQL Query variables from client side
     Variables map[string]interface{}
+
+    // GraphQL OperationName from client side, select which operation to execute when Query contains multiple operations
+    OperationName string
+
+    // receives parse, validate and resolve events of this request, DefaultTracer is used when nil
+    Tracer Tracer
 }
 
 type Result struct {
     Data      interface{} `json:"data"`
-    Errors []*ErrorInfo   `json:"errors"`
+    Errors []*ErrorInfo   `json:"errors,omitempty"`
 }
 
+/**
+ * Error Result Format
+ * {"message": "...", "locations": [{"line": 1, "column": 2}], "path": ["users", 2, "email"], "extensions": {"code": "NOT_FOUND"}}
+ */
 type ErrorInfo struct {
+    Message      string                 `json:"message"`
+    Locations []*ErrorLocation          `json:"locations,omitempty"`
+    // response path of field error, e.g. ["users", 2, "email"]
+    Path      []interface{}             `json:"path,omitempty"`
+    Extensions   map[string]interface{} `json:"extensions,omitempty"`
+}
+
+type ErrorLocation struct {
+    Line  int `json:"line"`
+    Col   int `json:"column"`
+}
+
+func newErrorLocation(location frontend.Location) *ErrorLocation {
+    return &ErrorLocation{location.Line, location.Column}
+}
+
+// build ErrorInfo from error, extensions are picked from ExtendedError
+func newErrorInfo(err error, errorLocation *ErrorLocation, path []interface{}) *ErrorInfo {
+    errorInfo := &ErrorInfo{Message: err.Error(), Path: path}
+    if errorLocation != nil {
+        errorInfo.Locations = []*ErrorLocation{errorLocation}
+    }
+    var extendedError ExtendedError
+    if errors.As(err, &extendedError) {
+        errorInfo.Extensions = extendedError.Extensions()
+    }
+    return errorInfo
+}
+
+// error with extensions, resolvers return it to attach extension fields (e.g. {"code": "NOT_FOUND"}) to ErrorInfo
+type ExtendedError interface {
+    error
+    Extensions() map[string]interface{}
+}
+
+type extendedError struct {
+    message    string
+    extensions map[string]interface{}
+}
+
+func (extended *extendedError) Error() string {
+    return extended.message
+}
+
+func (extended *extendedError) Extensions() map[string]interface{} {
+    return extended.extensions
+}
+
+func NewExtendedError(message string, extensions map[string]interface{}) ExtendedError {
+    return &extendedError{message, extensions}
+}
+
+// error with location in document, e.g. argument coercion failed
+type LocatedError struct {
     Message   string
     Location *ErrorLocation
+    // original error, for ExtendedError lookup
+    err       error
 }
 
-type ErrorLocation struct {
-    Line  int 
-    Col   int
+func (locatedError *LocatedError) Error() string {
+    return locatedError.Message
+}
+
+func (locatedError *LocatedError) Unwrap() error {
+    return locatedError.err
+}
+
+func newLocatedError(err error, location frontend.Location) *LocatedError {
+    if locatedError, ok := err.(*LocatedError); ok {
+        return locatedError
+    }
+    return &LocatedError{err.Error(), newErrorLocation(location), err}
 }
 
 // GlobalVariables for Query Variables, etc. 
 type GlobalVariables struct {
     // asserted query variables from request.Variables by VariableDefinition filtered
     QueryVariablesMap map[string]interface{}
+
+    // name mapped FragmentDefinitions from Document, for FragmentSpread execution
+    Fragments map[string]*frontend.FragmentDefinition
+
+    // request schema, for abstract type condition of fragments
+    Schema *Schema
+
+    // field errors during execution, in execution order
+    Errors []*ErrorInfo
+
+    // Tracer of request, nil for silent
+    Tracer Tracer
 }
 
 func (result *Result) SetErrorInfo(err error, errorLocation *ErrorLocation) {
-    errStr := fmt.Sprintf("%v", err)
-    errorInfo := ErrorInfo{errStr, errorLocation}
-    result.Errors = append(result.Errors, &errorInfo)
+    if locatedError, ok := err.(*LocatedError); ok && errorLocation == nil {
+        errorLocation = locatedError.Location
+    }
+    result.Errors = append(result.Errors, newErrorInfo(err, errorLocation, nil))
 }
 
 func DecodeVariables(inputVariables string) (map[string]interface{}, error) {
-    fmt.Printf("\n")
-    fmt.Printf("\033[31m[INTO] func DecodeVariables  \033[0m\n")
-
-    spewo := spew.ConfigState{ Indent: "    ", DisablePointerAddresses: true}
-
     var decodedVariables map[string]interface{}
     // no variables inputed
     if inputVariables == "" {
         return nil, nil
     }
     err := json.Unmarshal([]byte(inputVariables), &decodedVariables)
-    fmt.Printf("\033[33m    [DUMP] decodedVariables:  \033[0m\n")
-    spewo.Dump(decodedVariables)
     if err != nil {
         err := "executeQuery(): user input variables decode failed, please check input variables json syntax." 
         return nil, errors.New(err)
@@ -81,38 +163,45 @@ func getFieldName(field *frontend.Field) string {
     return field.Name.Value
 }
 
+// get response key from Field in AST, use Alias if present
+func getResponseKey(field *frontend.Field) string {
+    if field.Alias != nil {
+        return field.Alias.Name.Value
+    }
+    return field.Name.Value
+}
+
 func Execute(request Request) (*Result) {
     var document *frontend.Document
     var err       error
     result := Result{} 
-    g      := &GlobalVariables{}
-    // debugging
-    spewo := spew.ConfigState{ Indent: "    ", DisablePointerAddresses: true}
+    g      := &GlobalVariables{Tracer: getTracer(request)}
 
     // process input
+    startTime := time.Now()
     if document, err = frontend.Compile(request.Query); err != nil {
-        result.SetErrorInfo(err, nil)
+        if syntaxError, ok := err.(*frontend.SyntaxError); ok {
+            result.SetErrorInfo(errors.New("Syntax Error: " + syntaxError.Message), &ErrorLocation{syntaxError.Line, syntaxError.Column})
+        } else {
+            result.SetErrorInfo(err, nil)
+        }
+        trace(g.Tracer, &TraceEvent{Kind: TraceEventParse, Duration: time.Since(startTime), Errors: result.Errors})
         return &result
     }
-
-    // @todo: THE DOCUMENT NEED VALIDATE!
-    
-    if DUMP_FRONTEND {
-        fmt.Printf("\n")
-        fmt.Printf("\033[33m    [DUMP] Document:  \033[0m\n")
-        spewo.Dump(document)
-        if true {
-            result.Data = document
-            return &result
-        }
-        fmt.Printf("\033[33m    [DUMP] Request:  \033[0m\n")
-        spewo.Dump(request)
-        os.Exit(1)
+    trace(g.Tracer, &TraceEvent{Kind: TraceEventParse, Duration: time.Since(startTime)})
+
+    // validate document, all violations will be returned before any ResolveFunction runs
+    startTime = time.Now()
+    validationErrors := Validate(request.Schema, document)
+    trace(g.Tracer, &TraceEvent{Kind: TraceEventValidate, Duration: time.Since(startTime), Errors: validationErrors})
+    if len(validationErrors) > 0 {
+        result.Errors = validationErrors
+        return &result
     }
 
     // get top layer SelectionSet.Fields and request.Schema.ObjectFields
     var operationDefinition *frontend.OperationDefinition
-    if operationDefinition, err = document.GetOperationDefinition(); err != nil {
+    if operationDefinition, err = document.GetOperationDefinitionByName(request.OperationName); err != nil {
         result.SetErrorInfo(err, nil)
         return &result
     }
@@ -122,34 +211,36 @@ func Execute(request Request) (*Result) {
         result.SetErrorInfo(err, nil)
         return &result
     }
-    fmt.Printf("\033[33m    [DUMP] g.QueryVariablesMap:  \033[0m\n")
-    spewo.Dump(g.QueryVariablesMap)
+
+    // fill FragmentDefinitions
+    g.Fragments = document.GetFragmentDefinitions()
+    g.Schema    = &request.Schema
 
     selectionSet := operationDefinition.SelectionSet
     // selectionSetFields := getSelectionSetFields(selectionSet)
 
-    // get schema object fields
-    var objectFields ObjectFields
+    // get schema root object
+    var rootObject *Object
     operationType := operationDefinition.OperationType
     if operationType == frontend.OperationTypeQuery && request.Schema.Query != nil {
-        objectFields = request.Schema.GetQueryObjectFields()
+        rootObject = request.Schema.GetQueryObject()
     } else if operationType == frontend.OperationTypeMutation && request.Schema.Mutation != nil {
-        objectFields = request.Schema.GetMutationObjectFields()
+        rootObject = request.Schema.GetMutationObject()
     } else if operationType == frontend.OperationTypeSubscription && request.Schema.Subscription != nil {
-        objectFields = request.Schema.GetSubscriptionObjectFields()
+        rootObject = request.Schema.GetSubscriptionObject()
     } else {
         err = errors.New("Execute(): request.Schema should have Query or Mutation or Subscription field, please check server side Schema definition.")
         result.SetErrorInfo(err, nil)
         return &result
     }
-    fmt.Printf("\033[33m    [DUMP] objectFields:  \033[0m\n")
-    spewo.Dump(objectFields)
 
     // execute
-    fmt.Println("\n\n\033[33m////////////////////////////////////////// Executor Start ///////////////////////////////////////\033[0m\n")
-    resolvedResult, _ := resolveSelectionSet(g, request, selectionSet, objectFields, nil)
-    fmt.Printf("\033[33m    [DUMP] resolvedResult:  \033[0m\n")
-    spewo.Dump(resolvedResult)
+    resolvedResult, err := resolveSelectionSet(g, request, selectionSet, rootObject, nil, nil)
+    result.Errors = g.Errors
+    if err != nil {
+        // NonNull root field failed, data is null, the error is already recorded
+        return &result
+    }
     result.Data = resolvedResult
     return &result
 }
@@ -170,171 +261,158 @@ func getSelectionSetFields(selectionSet *frontend.SelectionSet) map[string]*fron
 }
 
 
-func resolveSelectionSet(g *GlobalVariables, request Request, selectionSet *frontend.SelectionSet, objectFields ObjectFields, resolvedData interface{}) (interface{}, error) {
-    selections  := selectionSet.GetSelections()
-    finalResult := make(map[string]interface{}, len(selections))
-    for _, selection := range selections {
+func resolveSelectionSet(g *GlobalVariables, request Request, selectionSet *frontend.SelectionSet, object *Object, resolvedData interface{}, path []interface{}) (interface{}, error) {
+    responseKeys, groupedFields := collectFields(g, object, selectionSet, make(map[string]bool), nil, make(map[string][]*frontend.Field))
+    finalResult := make(map[string]interface{}, len(responseKeys))
+    for _, responseKey := range responseKeys {
         // prepare data
-        field := selection.(*frontend.Field)
-        fieldName := getFieldName(field)
+        fields    := groupedFields[responseKey]
+        fieldName := getFieldName(fields[0])
+        fieldPath := appendPath(path, responseKey)
         // resolve Field
-        resolvedResult, _ := resolveField(g, request, fieldName, field, objectFields, resolvedData)
-        finalResult[fieldName] = resolvedResult   
+        resolvedResult, err := resolveField(g, request, fieldName, fields, object, resolvedData, fieldPath)
+        if err != nil {
+            err = recordFieldError(g, err, fields[0], fieldPath)
+            // null propagates to parent field when NonNull field failed
+            if isNonNullField(g.Schema, object, fieldName) {
+                return nil, err
+            }
+            resolvedResult = nil
+        }
+        finalResult[responseKey] = resolvedResult   
     }
     return finalResult, nil
 }
 
+// recorded field error, propagates as null to the nearest nullable parent without recording again
+type propagatedError struct {
+    err error
+}
 
-func getResolveFunction(fieldName string, objectFields ObjectFields) ResolveFunction {
-    resolveFunction := objectFields[fieldName].ResolveFunction
-    // build in type, provide default resolve function
-    if resolveFunction == nil {
-        return nil
-    }
-    return resolveFunction
-}
-
-
-func defaultValueTypeAssertion(value interface{}) (interface{}, error) {
-    // notice: the DefaultValue only accept const Value (Variables are not const Value)
-    if _, ok := value.(frontend.Variable); ok {
-        return nil, errors.New("defaultValueTypeAssertion(): the DefaultValue only accept const Value (Variables are not const Value).")
-    } else if ret, ok := value.(frontend.IntValue); ok {
-        return ret.Value, nil
-    } else if ret, ok := value.(frontend.FloatValue); ok {
-        return ret.Value, nil
-    } else if ret, ok := value.(frontend.StringValue); ok {
-        return ret.Value, nil
-    } else if ret, ok := value.(frontend.BooleanValue); ok {
-        return ret.Value, nil
-    } else if ret, ok := value.(frontend.NullValue); ok {
-        return ret.Value, nil
-    } else if ret, ok := value.(frontend.EnumValue); ok {
-        return ret.Value, nil
-    } else if ret, ok := value.(frontend.ListValue); ok {
-        return ret.Value, nil
-    } else if ret, ok := value.(frontend.ObjectValue); ok {
-        return ret.Value, nil
-    } else {
-        return nil, errors.New("defaultValueTypeAssertion(): illegal default value type '"+reflect.TypeOf(value).Elem().Name()+"'.")
-    }
+func (propagated *propagatedError) Error() string {
+    return propagated.err.Error()
 }
 
-func correctJsonUnmarshalIntValue(value interface{}, variableType frontend.Type) (int, error) {
-    // Int!
-    if  _, ok := variableType.(frontend.NonNullType); ok {
-        if val, ok := variableType.(frontend.NonNullType).Type.(*frontend.NamedType); ok {
-            if val.Value == Int.Name {
-                return int(value.(float64)), nil
-            }
-        }
-    // Int
-    } else if val, ok := variableType.(*frontend.NamedType); ok {
-        if val.Value == Int.Name {
-            return int(value.(float64)), nil
-        }
+/**
+ * record field error into GlobalVariables.Errors with response path and field location,
+ * every error only recorded once where it occurred.
+ */
+func recordFieldError(g *GlobalVariables, err error, field *frontend.Field, path []interface{}) error {
+    if _, ok := err.(*propagatedError); ok {
+        return err
+    }
+    location := newErrorLocation(field.Location)
+    if locatedError, ok := err.(*LocatedError); ok {
+        location = locatedError.Location
     }
-    // not an int at all
-    return 0, errors.New("correctJsonUnmarshalIntValue(): not a IntValue.")
+    g.Errors = append(g.Errors, newErrorInfo(err, location, path))
+    return &propagatedError{err}
 }
 
-// build QueryVariables map from user input request.Variables
-func getQueryVariablesMap(request Request, variableDefinitions []*frontend.VariableDefinition) (map[string]interface{}, error) {
-    fmt.Printf("\n")
-    fmt.Printf("\033[31m[INTO] func getQueryVariablesMap  \033[0m\n")
-    spewo := spew.ConfigState{ Indent: "    ", DisablePointerAddresses: true}
+// copy path and append response key or list index
+func appendPath(path []interface{}, key interface{}) []interface{} {
+    newPath := make([]interface{}, len(path), len(path)+1)
+    copy(newPath, path)
+    return append(newPath, key)
+}
 
-    fmt.Printf("\033[33m    [DUMP] variableDefinitions:  \033[0m\n")
-    spewo.Dump(variableDefinitions)
+// check ObjectField type is NonNull
+func isNonNullField(schema *Schema, object *Object, fieldName string) bool {
+    if objectField := getFieldDefinition(schema, object, fieldName); objectField != nil {
+        _, isNonNull := objectField.Type.(*NonNull)
+        return isNonNull
+    }
+    return false
+}
 
-    var err error
-	queryVariablesMap := make(map[string]interface{}, len(variableDefinitions))
-	
-    for _, variableDefinition := range variableDefinitions {
-        // detect value type & fill
-        variableName := variableDefinition.Variable.Value
-		variableType := variableDefinition.Type
-		if matchedValue, ok := request.Variables[variableName]; ok {
-            // convert float64 to int type for json.Unmarshal
-            if intValue, err := correctJsonUnmarshalIntValue(matchedValue, variableType); err == nil {
-                queryVariablesMap[variableName] = intValue
-            } else{
-                queryVariablesMap[variableName] = matchedValue
+/**
+ * CollectFields
+ * collect Fields from SelectionSet, follow the GraphQL specification CollectFields() algorithm.
+ * Fields of FragmentSpread and InlineFragment are merged into parent SelectionSet when the type
+ * condition matched, every FragmentSpread only expand once. Selections skipped by @skip or @include
+ * directives are excluded. responseKeys keep the order of fields
+ * in query, groupedFields are Fields mapped by response key (Alias or field name).
+ */
+func collectFields(g *GlobalVariables, object *Object, selectionSet *frontend.SelectionSet, visitedFragments map[string]bool, responseKeys []string, groupedFields map[string][]*frontend.Field) ([]string, map[string][]*frontend.Field) {
+    for _, selection := range selectionSet.GetSelections() {
+        switch s := selection.(type) {
+        case *frontend.Field:
+            if !shouldIncludeNode(g, s.Directives) {
+                continue
             }
-        // check NonNullType
-		} else if _, ok := variableType.(frontend.NonNullType); ok {
-            typeStr := ""
-            if val, ok := variableType.(frontend.NonNullType).Type.(*frontend.NamedType); ok {
-                typeStr = val.Value
-            } else {
-                typeStr = reflect.TypeOf(variableType.(frontend.NonNullType).Type).Elem().Name()
+            responseKey := getResponseKey(s)
+            if _, ok := groupedFields[responseKey]; !ok {
+                responseKeys = append(responseKeys, responseKey)
             }
-            return nil, errors.New("getQueryVariablesMap(): variable '"+variableName+"' is NonNullType '"+typeStr+"!', query variables not provided.")
-        // check DefaultValue
-        } else if variableDefinition.DefaultValue != nil {
-            if queryVariablesMap[variableName], err = defaultValueTypeAssertion(variableDefinition.DefaultValue); err != nil {
-                return nil, err
+            groupedFields[responseKey] = append(groupedFields[responseKey], s)
+        case *frontend.FragmentSpread:
+            if !shouldIncludeNode(g, s.Directives) {
+                continue
+            }
+            fragmentName := s.Name.Value
+            if visitedFragments[fragmentName] {
+                continue
+            }
+            visitedFragments[fragmentName] = true
+            fragmentDefinition, ok := g.Fragments[fragmentName]
+            if !ok || !doesFragmentTypeApply(g, object, fragmentDefinition.TypeCondition) {
+                continue
             }
+            responseKeys, groupedFields = collectFields(g, object, fragmentDefinition.SelectionSet, visitedFragments, responseKeys, groupedFields)
+        case *frontend.InlineFragment:
+            if !shouldIncludeNode(g, s.Directives) || !doesFragmentTypeApply(g, object, s.TypeCondition) {
+                continue
+            }
+            responseKeys, groupedFields = collectFields(g, object, s.SelectionSet, visitedFragments, responseKeys, groupedFields)
         }
     }
-    
-    fmt.Printf("\033[33m    [DUMP] queryVariablesMap:  \033[0m\n")
-    spewo.Dump(queryVariablesMap)
-
-    return queryVariablesMap, nil
+    return responseKeys, groupedFields
 }
 
-// build Field.Arguments map from GlobalVariables.QueryVariablesMap
-func getFieldArgumentsMap(g *GlobalVariables, arguments []*frontend.Argument) (map[string]interface{}, error) {
-    fmt.Printf("\n")
-    fmt.Printf("\033[31m[INTO] func getFieldArgumentsMap  \033[0m\n")
-    spewo := spew.ConfigState{ Indent: "    ", DisablePointerAddresses: true}
+// check fragment type condition matches object, fragment without type condition always matches.
+// Interface or Union type condition matches when object is one of it's possible types
+func doesFragmentTypeApply(g *GlobalVariables, object *Object, typeCondition *frontend.Name) bool {
+    if typeCondition == nil {
+        return true
+    }
+    if object.Name == typeCondition.Value {
+        return true
+    }
+    if g.Schema == nil {
+        return false
+    }
+    conditionalType := g.Schema.GetType(typeCondition.Value)
+    if !isAbstractType(conditionalType) {
+        return false
+    }
+    return g.Schema.IsPossibleType(conditionalType, object)
+}
 
-    fieldArgumentsMap := make(map[string]interface{}, len(arguments))
-    
-    for _, argument := range arguments {
-        // detect argument type & fill
-        argumentName  := argument.Name.Value
-        argumentValue := argument.Value
-        // assert Argument.Value type
-        if _, ok := argumentValue.(frontend.Variable); ok {
-            // Variable type, resolve referenced value from GlobalVariables.QueryVariablesMap
-            if matched, ok := g.QueryVariablesMap[argumentName]; ok {
-                fieldArgumentsMap[argumentName] = matched
-            } else {
-                err := "getFieldArgumentsMap(): Field.Arguments referenced variable $"+argumentName+", but it was NOT defined at OperationDefinition.VariableDefinitions, please check your GraphQL OperationDefinition syntax."
-                return nil, errors.New(err)
-            }
-        } else if val, ok := argumentValue.(frontend.IntValue); ok {
-            fieldArgumentsMap[argumentName] = val.Value
-        } else if val, ok := argumentValue.(frontend.FloatValue); ok {
-            fieldArgumentsMap[argumentName] = val.Value
-        } else if val, ok := argumentValue.(frontend.StringValue); ok {
-            fieldArgumentsMap[argumentName] = val.Value
-        } else if val, ok := argumentValue.(frontend.BooleanValue); ok {
-            fieldArgumentsMap[argumentName] = val.Value
-        } else if val, ok := argumentValue.(frontend.NullValue); ok {
-            fieldArgumentsMap[argumentName] = val.Value
-        } else if val, ok := argumentValue.(frontend.EnumValue); ok {
-            fieldArgumentsMap[argumentName] = val.Value
-        } else if val, ok := argumentValue.(frontend.ListValue); ok {
-            fieldArgumentsMap[argumentName] = val.Value
-        } else if val, ok := argumentValue.(frontend.ObjectValue); ok {
-            fieldArgumentsMap[argumentName] = val.Value
-        } else {
-            err := "getFieldArgumentsMap(): Field.Arguments.Argument type assert failed, please check your GraphQL Field.Arguments.Argument syntax."
-            return nil, errors.New(err)
+// merge SelectionSet of same response key Fields for sub-Field resolving
+func mergeSelectionSets(fields []*frontend.Field) *frontend.SelectionSet {
+    if len(fields) == 1 {
+        return fields[0].SelectionSet
+    }
+    var selectionSet *frontend.SelectionSet
+    for _, field := range fields {
+        if field.SelectionSet == nil {
+            continue
+        }
+        if selectionSet == nil {
+            selectionSet = &frontend.SelectionSet{LineNum: field.SelectionSet.LineNum, Location: field.SelectionSet.Location}
         }
+        selectionSet.Selections = append(selectionSet.Selections, field.SelectionSet.Selections...)
     }
-    
-    fmt.Printf("\033[33m    [DUMP] fieldArgumentsMap:  \033[0m\n")
-    spewo.Dump(fieldArgumentsMap)
-
-    return fieldArgumentsMap, nil
+    return selectionSet
 }
 
+
 func checkIfInputArgumentsAvaliable(inputArguments map[string]interface{}, targetObjectFieldArguments *Arguments) (bool, error) {
     for argumentName, _ := range inputArguments {
+        if targetObjectFieldArguments == nil {
+            err := "checkIfInputArgumentsAvaliable(): input argument '"+argumentName+"' does not defined in schema."
+            return false, errors.New(err)
+        }
         if _, ok := (*targetObjectFieldArguments)[argumentName]; !ok {
             err := "checkIfInputArgumentsAvaliable(): input argument '"+argumentName+"' does not defined in schema."
             return false, errors.New(err)
@@ -343,74 +421,109 @@ func checkIfInputArgumentsAvaliable(inputArguments map[string]interface{}, targe
     return true, nil
 }
 
-func resolveField(g *GlobalVariables, request Request, fieldName string, field *frontend.Field, objectFields ObjectFields, resolvedData interface{}) (interface{}, error) {
+func resolveField(g *GlobalVariables, request Request, fieldName string, fields []*frontend.Field, object *Object, resolvedData interface{}, path []interface{}) (interface{}, error) {
     var err error
-    fmt.Printf("\n")
-    fmt.Printf("\033[31m[INTO] func resolveField  \033[0m\n")
-    spewo := spew.ConfigState{ Indent: "    ", DisablePointerAddresses: true}
-    fmt.Printf("\033[33m    [DUMP] fieldName:  \033[0m\n")
-    spewo.Dump(fieldName)
-    fmt.Printf("\033[33m    [DUMP] field:  \033[0m\n")
-    spewo.Dump(field)
-    fmt.Printf("\033[33m    [DUMP] objectFields:  \033[0m\n")
-    spewo.Dump(objectFields)
-
-    if _, ok := objectFields[fieldName]; !ok {
+    // same response key fields are merged, arguments are taken from the first one
+    field := fields[0]
+
+    // schema defined field or introspection meta-field
+    objectField := getFieldDefinition(g.Schema, object, fieldName)
+    if objectField == nil {
         err := "resolveField(): input document field name "+fieldName+" does not defined in schema."
         return nil, errors.New(err)
     }
     
-    // check resolve function or extend last resolved data 
-    resolveFunction := getResolveFunction(fieldName, objectFields)
-
+    // check resolve function or pick field value from last resolved data
+    resolveFunction := objectField.ResolveFunction
     if resolveFunction == nil {
-        fmt.Printf("\033[33m    [HIT!] resolveFunction == nil  \033[0m\n")
-        resolveFunction = func (p ResolveParams) (interface{}, error) {
-            return p, nil
-        }
+        resolveFunction = defaultResolveFunction(fieldName)
+    }
+    // wrap resolve function by schema defined directives
+    if resolveFunction, err = applyDirectives(g, request, field.Directives, resolveFunction); err != nil {
+        return nil, err
     }
-    // no context resovedData input, check GraphQL Request Arguments
-    if resolvedData == nil {
-        var resolveParams ResolveParams
 
-        // get field arguments
-        if resolveParams.Arguments, err = getFieldArgumentsMap(g, field.Arguments); err != nil {
-            return nil, err
-        }
-        // pass arguments into user defined resolve function
-        if resolvedData, err = resolveFunction(resolveParams); err != nil {
-            return nil, err
+    // last resolved data as context, and GraphQL Request Arguments
+    var resolveParams ResolveParams
+    resolveParams.Context = resolvedData
+    resolveParams.Schema  = g.Schema
+    if resolveParams.Arguments, err = coerceArgumentValues(g, field.Arguments, objectField.Arguments); err != nil {
+        return nil, newLocatedError(err, field.Location)
+    }
+    // pass arguments into resolve function
+    var resolvedFieldData interface{}
+    startTime := time.Now()
+    resolvedFieldData, err = resolveFunction(resolveParams)
+    traceResolve(g, object, field, path, startTime, err)
+    if err != nil {
+        return nil, err
+    }
+    if isNullValue(resolvedFieldData) {
+        if _, ok := objectField.Type.(*NonNull); ok {
+            err := "resolveField(): cannot return null for non-nullable field "+fieldName+"."
+            return nil, errors.New(err)
         }
-
-        fmt.Printf("\033[33m    [DUMP] resolvedData:  \033[0m\n")
-        spewo.Dump(resolvedData)
-        // check resolvedData match input ObjectField.Type
-        if ok, err := resolvedDataTypeChecker(fieldName, resolvedData, objectFields[fieldName].Type); !ok {
+        return nil, nil
+    }
+    // check user defined ResolveFunction result match input ObjectField.Type
+    if objectField.ResolveFunction != nil {
+        if ok, err := resolvedDataTypeChecker(fieldName, resolvedFieldData, objectField.Type); !ok {
             return nil, err
         }
     }
-    fmt.Printf("\033[33m    [DUMP] objectFields[documentFieldName]:  \033[0m\n")
-    spewo.Dump(objectFields[fieldName])
-    fmt.Printf("\033[33m    [DUMP] resolvedData:  \033[0m\n")
-    spewo.Dump(resolvedData)
 
     // resolve sub-Field
-    targetSelectionSet := field.SelectionSet
-    targetObjectField := objectFields[fieldName]
-    targetObjectFieldType := objectFields[fieldName].Type
+    targetSelectionSet := mergeSelectionSets(fields)
     // go
-    resolvedSubData, _ := resolveSubField(g, request, targetSelectionSet, targetObjectField, targetObjectFieldType, resolvedData)
-    return resolvedSubData, nil
+    return resolveSubField(g, request, targetSelectionSet, field, objectField.Type, resolvedFieldData, path)
+}
+
+// default ResolveFunction for ObjectField without ResolveFunction, pick target field value from last resolved data
+func defaultResolveFunction(fieldName string) ResolveFunction {
+    return func (p ResolveParams) (interface{}, error) {
+        return getResolvedDataTargetFieldValue(p.Context, fieldName), nil
+    }
+}
+
+/**
+ * check NonNull type arguments are provided and not null, arguments map should be coerced already
+ */
+func checkRequiredArguments(argumentsMap map[string]interface{}, targetArguments *Arguments) error {
+    if targetArguments == nil {
+        return nil
+    }
+    for argumentName, targetArgument := range *targetArguments {
+        if _, ok := targetArgument.Type.(*NonNull); !ok {
+            continue
+        }
+        if argumentsMap[argumentName] == nil {
+            err := "checkRequiredArguments(): argument \""+argumentName+"\" of required type \""+targetArgument.Type.GetName()+"\" was not provided."
+            return errors.New(err)
+        }
+    }
+    return nil
+}
+
+// check nil and typed nil (e.g. (*User)(nil)) value
+func isNullValue(value interface{}) bool {
+    if value == nil {
+        return true
+    }
+    reflectValue := reflect.ValueOf(value)
+    switch reflectValue.Kind() {
+    case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+        return reflectValue.IsNil()
+    }
+    return false
 }
 
 func resolvedDataTypeChecker(fieldName string, resolvedData interface{}, expectedType FieldType) (bool, error) {
-    fmt.Printf("\n")
-    fmt.Printf("\033[31m[INTO] func resolveSubField  \033[0m\n")
     errorInfo := func(fieldName string, expected string, but string) error {
         err := "resolveField(): schema defined ObjectField '"+fieldName+"' Type is '"+expected+"', but ResolveFunction return type is '"+but+"', please check your schema."
         return errors.New(err)
     }
-    resolvedDataType := reflect.TypeOf(resolvedData)
+    expectedType = getNullableType(expectedType)
+    resolvedDataType := reflect.Indirect(reflect.ValueOf(resolvedData)).Type()
     switch resolvedDataType.Kind() {
         case reflect.Slice:
             if _, ok := expectedType.(*List); ok {
@@ -426,9 +539,12 @@ func resolvedDataTypeChecker(fieldName string, resolvedData interface{}, expecte
             if _, ok := expectedType.(*List); ok {
                 return true, nil
             }
+            if _, ok := expectedType.(*Object); ok || isAbstractType(expectedType) {
+                return true, nil
+            }
             return false, errorInfo(fieldName, reflect.TypeOf(expectedType).Elem().Name(), "slice, array or map")
         case reflect.Struct:
-            if _, ok := expectedType.(*Object); ok {
+            if _, ok := expectedType.(*Object); ok || isAbstractType(expectedType) {
                 return true, nil
             }
             return false, errorInfo(fieldName, reflect.TypeOf(expectedType).Elem().Name(), "struct")
@@ -436,113 +552,185 @@ func resolvedDataTypeChecker(fieldName string, resolvedData interface{}, expecte
             if _, ok := expectedType.(*Scalar); ok {
                 return true, nil
             }
+            if _, ok := expectedType.(*Enum); ok {
+                return true, nil
+            }
     }
-    return false, errorInfo(fieldName, reflect.TypeOf(expectedType).Elem().Name(), reflect.TypeOf(resolvedData).Elem().Name())
+    return false, errorInfo(fieldName, reflect.TypeOf(expectedType).Elem().Name(), resolvedDataType.Name())
 }
 
 
-func resolveSubField(g *GlobalVariables, request Request, selectionSet *frontend.SelectionSet, objectField *ObjectField, targetType FieldType, resolvedData interface{}) (interface{}, error) {
-    fmt.Printf("\n")
-    fmt.Printf("\033[31m[INTO] func resolveSubField  \033[0m\n")
+func resolveSubField(g *GlobalVariables, request Request, selectionSet *frontend.SelectionSet, field *frontend.Field, targetType FieldType, resolvedData interface{}, path []interface{}) (interface{}, error) {
     // get resolve target type
+    if nonNull, ok := targetType.(*NonNull); ok {
+        if isNullValue(resolvedData) {
+            err := "resolveSubField(): cannot return null for non-nullable field "+getFieldName(field)+"."
+            return nil, errors.New(err)
+        }
+        resolvedSubData, err := resolveSubField(g, request, selectionSet, field, nonNull.OfType, resolvedData, path)
+        if err == nil && resolvedSubData == nil {
+            err = errors.New("resolveSubField(): cannot return null for non-nullable field "+getFieldName(field)+".")
+        }
+        return resolvedSubData, err
+    }
+
+    if isNullValue(resolvedData) {
+        return nil, nil
+    }
 
-    if _, ok := targetType.(*List); ok {
-        return resolveListData(g, request, selectionSet, objectField, resolvedData)
+    if list, ok := targetType.(*List); ok {
+        return resolveListData(g, request, selectionSet, field, list, resolvedData, path)
     } 
 
-    if _, ok := targetType.(*Scalar); ok {
-        return resolveScalarData(g, request, selectionSet, objectField, resolvedData)
+    if scalar, ok := targetType.(*Scalar); ok {
+        return resolveScalarData(g, request, selectionSet, scalar, resolvedData)
     }
 
-    if _, ok := targetType.(*Object); ok {
-        return resolveObjectData(g, request, selectionSet, objectField, resolvedData)
+    if enum, ok := targetType.(*Enum); ok {
+        return resolveEnumData(enum, resolvedData)
+    }
+
+    if object, ok := targetType.(*Object); ok {
+        return resolveObjectData(g, request, selectionSet, object, resolvedData, path)
+    }
+
+    if isAbstractType(targetType) {
+        return resolveAbstractData(g, request, selectionSet, targetType, resolvedData, path)
     }
     return nil, nil
     
 }
 
-func resolveListData(g *GlobalVariables, request Request, selectionSet *frontend.SelectionSet, objectField *ObjectField, resolvedData interface{}) (interface{}, error) {
-    fmt.Printf("\n")
-    fmt.Printf("\033[31m[INTO] func resolveListData  \033[0m\n")
-    // spewo := spew.ConfigState{ Indent: "    ", DisablePointerAddresses: true}
-
-    resolvedDataValue := reflect.ValueOf(resolvedData)
-    targetObjectFields := objectField.Type.(*List).Payload.(*Object).Fields
+/**
+ * CompleteValue for List
+ * every list item is completed by the payload type of list, payload type could be any output type
+ * (Scalar, Enum, Object, Interface, Union, nested List or NonNull). Failed NonNull item makes the
+ * whole list null, nullable item failed is null.
+ */
+func resolveListData(g *GlobalVariables, request Request, selectionSet *frontend.SelectionSet, field *frontend.Field, list *List, resolvedData interface{}, path []interface{}) (interface{}, error) {
+    resolvedDataValue := reflect.Indirect(reflect.ValueOf(resolvedData))
+    if resolvedDataValue.Kind() != reflect.Slice && resolvedDataValue.Kind() != reflect.Array {
+        err := "resolveListData(): field "+getFieldName(field)+" is List type, but resolved data is not slice or array."
+        return nil, errors.New(err)
+    }
+    _, isNonNullPayload := list.Payload.(*NonNull)
     // allocate space for list data returns
     finalResult := make([]interface{}, 0, resolvedDataValue.Len())
     // traverse list
     for i:=0; i<resolvedDataValue.Len(); i++ {
         resolvedDataElement := resolvedDataValue.Index(i).Interface()
-        // fmt.Printf("\033[33m    [DUMP] resolvedDataElement:  \033[0m\n")
-        // spewo.Dump(resolvedDataElement)
-        // fmt.Printf("\033[33m    [DUMP] objectField:  \033[0m\n")
-        // spewo.Dump(objectField)
-        // fmt.Printf("\033[33m    [DUMP] selectionSet:  \033[0m\n")
-        // spewo.Dump(selectionSet)
-        // execute
-        selectionSetResult, _ := resolveSelectionSet(g, request, selectionSet, targetObjectFields, resolvedDataElement)
-        finalResult = append(finalResult, selectionSetResult)
+        // execute, every element of Interface or Union list may be a different Object
+        itemPath := appendPath(path, i)
+        resolvedElement, err := resolveSubField(g, request, selectionSet, field, list.Payload, resolvedDataElement, itemPath)
+        if err != nil {
+            err = recordFieldError(g, err, field, itemPath)
+            if isNonNullPayload {
+                return nil, err
+            }
+            resolvedElement = nil
+        }
+        finalResult = append(finalResult, resolvedElement)
     }
     return finalResult, nil
 }
 
-func resolveScalarData(g *GlobalVariables, request Request, selectionSet *frontend.SelectionSet, objectField *ObjectField, resolvedData interface{}) (interface{}, error) {
-    fmt.Printf("\n")
-    fmt.Printf("\033[31m[INTO] func resolveScalarData  \033[0m\n")
-    spewo := spew.ConfigState{ Indent: "    ", DisablePointerAddresses: true}
-
-    fmt.Printf("\033[33m    [DUMP] selectionSet:  \033[0m\n")
-    spewo.Dump(selectionSet)
-    fmt.Printf("\033[33m    [DUMP] objectField:  \033[0m\n")
-    spewo.Dump(objectField)
-    fmt.Printf("\033[33m    [DUMP] resolvedData:  \033[0m\n")
-    spewo.Dump(resolvedData)
-
-    // call resolve function
-    resolveFunction := objectField.Type.(*Scalar).ResolveFunction
-    targetFieldName := objectField.Name
-    r0 := getResolvedDataTargetFieldValue(resolvedData, targetFieldName)
-    fmt.Printf("\033[33m    [DUMP] getResolvedDataTargetFieldValue result:  \033[0m\n")
-    spewo.Dump(r0)
+func resolveScalarData(g *GlobalVariables, request Request, selectionSet *frontend.SelectionSet, scalar *Scalar, resolvedData interface{}) (interface{}, error) {
+    // call resolve function, scalar without ResolveFunction returns resolved data directly
+    resolveFunction := scalar.ResolveFunction
+    if resolveFunction == nil {
+        return resolvedData, nil
+    }
     // convert 
     p := ResolveParams{}
-    p.Context = r0
-    r1, _ := resolveFunction(p)
-    fmt.Printf("\033[43;37m    [DUMP] resolveFunction result:  \033[0m\n")
-    spewo.Dump(r1)
-    return r1, nil
+    p.Context = reflect.ValueOf(resolvedData)
+    return resolveFunction(p)
 }
 
-func resolveObjectData(g *GlobalVariables, request Request, selectionSet *frontend.SelectionSet, objectField *ObjectField, resolvedData interface{}) (interface{}, error) {
-    fmt.Printf("\n")
-    fmt.Printf("\033[31m[INTO] func resolveObjectData  \033[0m\n")
-
-    spewo := spew.ConfigState{ Indent: "    ", DisablePointerAddresses: true}
+// serialize resolved data to enum name
+func resolveEnumData(enum *Enum, resolvedData interface{}) (interface{}, error) {
+    if enumName, ok := enum.Serialize(resolvedData); ok {
+        return enumName, nil
+    }
+    err := fmt.Sprintf("resolveEnumData(): enum \"%s\" cannot represent value: %v.", enum.Name, resolvedData)
+    return nil, errors.New(err)
+}
 
-    fmt.Printf("\033[33m    [DUMP] selectionSet:  \033[0m\n")
-    spewo.Dump(selectionSet)
-    fmt.Printf("\033[33m    [DUMP] objectField:  \033[0m\n")
-    spewo.Dump(objectField)
-    fmt.Printf("\033[33m    [DUMP] resolvedData:  \033[0m\n")
-    spewo.Dump(resolvedData)
+func resolveObjectData(g *GlobalVariables, request Request, selectionSet *frontend.SelectionSet, object *Object, resolvedData interface{}, path []interface{}) (interface{}, error) {
+    // go
+    return resolveSelectionSet(g, request, selectionSet, object, resolvedData, path)
+}
 
+func resolveAbstractData(g *GlobalVariables, request Request, selectionSet *frontend.SelectionSet, abstractType FieldType, resolvedData interface{}, path []interface{}) (interface{}, error) {
     // go
-    targetObjectFields := objectField.Type.(*Object).Fields
-    selectionSetResult, _ := resolveSelectionSet(g, request, selectionSet, targetObjectFields, resolvedData)
-    return selectionSetResult, nil
+    targetObject, err := resolveRuntimeObject(request, abstractType, resolvedData)
+    if err != nil {
+        return nil, err
+    }
+    return resolveSelectionSet(g, request, selectionSet, targetObject, resolvedData, path)
 }
 
-func getResolvedDataTargetFieldValue(resolvedData interface{}, targetFieldName string) (interface{}) {
-    fmt.Printf("\033[31m[INTO] func getResolvedDataTargetFieldValue  \033[0m\n")
-    spewo := spew.ConfigState{ Indent: "    ", DisablePointerAddresses: true}
+/**
+ * ResolveAbstractType
+ * determine the Object type of Interface or Union resolved data, use ResolveType of abstract type
+ * if defined, otherwise try IsTypeOf of every possible Object type. Object type returns itself.
+ */
+func resolveRuntimeObject(request Request, targetType Type, resolvedData interface{}) (*Object, error) {
+    var resolveType ResolveTypeFunction
+    switch t := targetType.(type) {
+    case *Object:
+        return t, nil
+    case *Interface:
+        resolveType = t.ResolveType
+    case *Union:
+        resolveType = t.ResolveType
+    default:
+        err := "resolveRuntimeObject(): type "+targetType.GetName()+" is not Object, Interface or Union."
+        return nil, errors.New(err)
+    }
 
-    val := reflect.ValueOf(resolvedData)
-    spewo.Dump(resolvedData)
-    spewo.Dump(val)
+    var targetObject *Object
+    if resolveType != nil {
+        targetObject = resolveType(ResolveTypeParams{Value: resolvedData})
+    } else {
+        for _, possibleType := range request.Schema.GetPossibleTypes(targetType) {
+            if possibleType.IsTypeOf != nil && possibleType.IsTypeOf(IsTypeOfParams{Value: resolvedData}) {
+                targetObject = possibleType
+                break
+            }
+        }
+    }
+    if targetObject == nil {
+        err := "resolveRuntimeObject(): abstract type "+targetType.GetName()+" must resolve to an Object type at runtime, please check ResolveType or IsTypeOf function."
+        return nil, errors.New(err)
+    }
+    if !request.Schema.IsPossibleType(targetType, targetObject) {
+        err := "resolveRuntimeObject(): runtime Object type "+targetObject.Name+" is not a possible type for "+targetType.GetName()+"."
+        return nil, errors.New(err)
+    }
+    return targetObject, nil
+}
 
-    for i := 0; i < val.Type().NumField(); i++ {
-        if val.Type().Field(i).Tag.Get("json") == targetFieldName {
-            return reflect.Indirect(val).FieldByName(val.Type().Field(i).Name)
+// pick target field value from struct (by json tag) or map (by key) resolved data
+func getResolvedDataTargetFieldValue(resolvedData interface{}, targetFieldName string) (interface{}) {
+    val := reflect.Indirect(reflect.ValueOf(resolvedData))
+    switch val.Kind() {
+    case reflect.Map:
+        if val.Type().Key().Kind() != reflect.String {
+            return nil
+        }
+        if target := val.MapIndex(reflect.ValueOf(targetFieldName).Convert(val.Type().Key())); target.IsValid() {
+            return target.Interface()
+        }
+    case reflect.Struct:
+        for i := 0; i < val.Type().NumField(); i++ {
+            structField := val.Type().Field(i)
+            // unexported field
+            if structField.PkgPath != "" {
+                continue
+            }
+            if strings.Split(structField.Tag.Get("json"), ",")[0] == targetFieldName {
+                return val.Field(i).Interface()
+            }
         }
     }
     return nil
@@ -566,7 +754,7 @@ type List struct {
 }
 
 func (list *List) GetName() string {
-    return fmt.Sprintf("%v", list.Payload)
+    return "["+list.Payload.GetName()+"]"
 }
 
 func NewList(i Type) *List {
@@ -581,20 +769,69 @@ func NewList(i Type) *List {
     return list
 }
 
+/**
+ * NonNull types
+ * By default, all types in GraphQL are nullable. NonNull type wraps an underlying type, and acts
+ * identically to that wrapped type, with the exception that null is not a valid response for the
+ * wrapping type. When NonNull field resolved null, the null propagates to the nearest nullable parent.
+ */
+
+type NonNull struct {
+    OfType Type
+}
+
+func (nonNull *NonNull) GetName() string {
+    return nonNull.OfType.GetName()+"!"
+}
+
+func NewNonNull(i Type) *NonNull {
+    nonNull := &NonNull{}
+
+    if i == nil {
+        log.Fatal("NewNonNull() input is nil")
+        return nonNull
+    }
+    if _, ok := i.(*NonNull); ok {
+        log.Fatal("NewNonNull() input is already NonNull")
+        return nonNull
+    }
+
+    nonNull.OfType = i
+    return nonNull
+}
+
+// unwrap NonNull to get the nullable type
+func getNullableType(targetType Type) Type {
+    if nonNull, ok := targetType.(*NonNull); ok {
+        return nonNull.OfType
+    }
+    return targetType
+}
+
 // scalar definition
 
 type ScalarTemplate struct {
     Name            string          `json:name`
     Description     string          `json:description`
     ResolveFunction ResolveFunction `json:"-"`
+    ParseValue      ParseValueFunction   `json:"-"`
+    ParseLiteral    ParseLiteralFunction `json:"-"`
 }
 
 type Scalar struct {
     Name            string          `json:name`
     Description     string          `json:description`
     ResolveFunction ResolveFunction `json:"-"`
+    ParseValue      ParseValueFunction   `json:"-"`
+    ParseLiteral    ParseLiteralFunction `json:"-"`
 }
 
+// parse JSON decoded variable value to scalar input value
+type ParseValueFunction func(value interface{}) (interface{}, error)
+
+// parse literal Value from document to scalar input value
+type ParseLiteralFunction func(value frontend.Value) (interface{}, error)
+
 func (scalar *Scalar) GetName() string {
     return scalar.Name
 }
@@ -612,8 +849,8 @@ func NewScalar(scalarTemplate ScalarTemplate) *Scalar {
     scalar.Name            = scalarTemplate.Name
     scalar.Description     = scalarTemplate.Description
     scalar.ResolveFunction = scalarTemplate.ResolveFunction
-
-    // @todo: Scalar should provide serialize, parse value, parse literal function.
+    scalar.ParseValue      = scalarTemplate.ParseValue
+    scalar.ParseLiteral    = scalarTemplate.ParseLiteral
     
     return scalar
 }
@@ -626,6 +863,24 @@ var Int = NewScalar(ScalarTemplate{
     ResolveFunction: func (p ResolveParams) (interface{}, error) {
         return p.Context.(reflect.Value).Int(), nil
     },
+    ParseValue: func (value interface{}) (interface{}, error) {
+        switch v := value.(type) {
+        case int:
+            return v, nil
+        // json.Unmarshal decodes all numbers to float64
+        case float64:
+            if v == math.Trunc(v) && v <= math.MaxInt32 && v >= math.MinInt32 {
+                return int(v), nil
+            }
+        }
+        return nil, fmt.Errorf("Int.ParseValue(): Int cannot represent non-integer value: %v.", value)
+    },
+    ParseLiteral: func (value frontend.Value) (interface{}, error) {
+        if intValue, ok := value.(frontend.IntValue); ok {
+            return intValue.Value, nil
+        }
+        return nil, errors.New("Int.ParseLiteral(): Int cannot represent non-integer value.")
+    },
 })
 
 var String = NewScalar(ScalarTemplate{
@@ -634,14 +889,39 @@ var String = NewScalar(ScalarTemplate{
     ResolveFunction: func (p ResolveParams) (interface{}, error) {
         return p.Context.(reflect.Value).String(), nil
     },
+    ParseValue: func (value interface{}) (interface{}, error) {
+        if stringValue, ok := value.(string); ok {
+            return stringValue, nil
+        }
+        return nil, fmt.Errorf("String.ParseValue(): String cannot represent a non string value: %v.", value)
+    },
+    ParseLiteral: func (value frontend.Value) (interface{}, error) {
+        if stringValue, ok := value.(frontend.StringValue); ok {
+            return stringValue.Value, nil
+        }
+        return nil, errors.New("String.ParseLiteral(): String cannot represent a non string value.")
+    },
 })
 
+// exposed to clients as "Boolean" as defined by the spec, query declaring variable as "Bool" gets unknown type error
 var Bool = NewScalar(ScalarTemplate{
-    Name: "Bool",
-    Description: "GraphQL Bool type",
+    Name: "Boolean",
+    Description: "GraphQL Boolean type",
     ResolveFunction: func (p ResolveParams) (interface{}, error) {
         return p.Context.(reflect.Value).Bool(), nil
     },
+    ParseValue: func (value interface{}) (interface{}, error) {
+        if boolValue, ok := value.(bool); ok {
+            return boolValue, nil
+        }
+        return nil, fmt.Errorf("Boolean.ParseValue(): Boolean cannot represent a non boolean value: %v.", value)
+    },
+    ParseLiteral: func (value frontend.Value) (interface{}, error) {
+        if booleanValue, ok := value.(frontend.BooleanValue); ok {
+            return booleanValue.Value, nil
+        }
+        return nil, errors.New("Boolean.ParseLiteral(): Boolean cannot represent a non boolean value.")
+    },
 })
 
 var Float = NewScalar(ScalarTemplate{
@@ -650,20 +930,230 @@ var Float = NewScalar(ScalarTemplate{
     ResolveFunction: func (p ResolveParams) (interface{}, error) {
         return p.Context.(reflect.Value).Float(), nil
     },
+    // Int input value is accepted for Float
+    ParseValue: func (value interface{}) (interface{}, error) {
+        switch v := value.(type) {
+        case float64:
+            return v, nil
+        case int:
+            return float64(v), nil
+        }
+        return nil, fmt.Errorf("Float.ParseValue(): Float cannot represent non numeric value: %v.", value)
+    },
+    ParseLiteral: func (value frontend.Value) (interface{}, error) {
+        switch v := value.(type) {
+        case frontend.FloatValue:
+            return v.Value, nil
+        case frontend.IntValue:
+            return float64(v.Value), nil
+        }
+        return nil, errors.New("Float.ParseLiteral(): Float cannot represent non numeric value.")
+    },
 })
 
+// ID is serialized as String, String and Int input value are accepted
+var ID = NewScalar(ScalarTemplate{
+    Name: "ID",
+    Description: "GraphQL ID type",
+    ResolveFunction: func (p ResolveParams) (interface{}, error) {
+        value := p.Context.(reflect.Value)
+        switch value.Kind() {
+        case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+            return strconv.FormatInt(value.Int(), 10), nil
+        case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+            return strconv.FormatUint(value.Uint(), 10), nil
+        case reflect.String:
+            return value.String(), nil
+        }
+        return nil, errors.New("ID.ResolveFunction(): ID cannot represent value: "+value.Type().Name()+".")
+    },
+    ParseValue: func (value interface{}) (interface{}, error) {
+        switch v := value.(type) {
+        case string:
+            return v, nil
+        case int:
+            return strconv.Itoa(v), nil
+        case float64:
+            if v == math.Trunc(v) {
+                return strconv.FormatFloat(v, 'f', -1, 64), nil
+            }
+        }
+        return nil, fmt.Errorf("ID.ParseValue(): ID cannot represent value: %v.", value)
+    },
+    ParseLiteral: func (value frontend.Value) (interface{}, error) {
+        switch v := value.(type) {
+        case frontend.StringValue:
+            return v.Value, nil
+        case frontend.IntValue:
+            return strconv.Itoa(v.Value), nil
+        }
+        return nil, errors.New("ID.ParseLiteral(): ID cannot represent a non-string and non-integer value.")
+    },
+})
+
+/**
+ * Enum Syntax
+ * GraphQL Enum types represent leaf values in a GraphQL type system which can be one of a set of
+ * possible values. Enum names are serialized in output, and mapped to Go values in input.
+ */
+
+type EnumValues map[string]*EnumValue
+
+type EnumValue struct {
+    Name              string
+    Value             interface{} `json:"-"`
+    Description       string
+    DeprecationReason string
+}
+
+type EnumTemplate struct {
+    Name        string
+    Description string
+    Values      EnumValues
+}
+
+type Enum struct {
+    Name        string
+    Description string
+    Values      EnumValues
+}
+
+func (enum *Enum) GetName() string {
+    return enum.Name
+}
+
+func (enum *Enum) GetValues() EnumValues {
+    return enum.Values
+}
+
+// get enum name by Go value
+func (enum *Enum) Serialize(value interface{}) (string, bool) {
+    for enumName, enumValue := range enum.Values {
+        if reflect.DeepEqual(enumValue.Value, value) {
+            return enumName, true
+        }
+    }
+    return "", false
+}
+
+// get Go value by enum name
+func (enum *Enum) ParseValue(enumName string) (interface{}, bool) {
+    if enumValue, ok := enum.Values[enumName]; ok {
+        return enumValue.Value, true
+    }
+    return nil, false
+}
+
+func NewEnum(enumTemplate EnumTemplate) (*Enum, error) {
+    enum := &Enum{}
+
+    // check enum input
+    if enumTemplate.Name == "" {
+        err := errors.New("EnumTemplate.Name is not defined")
+        return nil, err
+    }
+    if len(enumTemplate.Values) == 0 {
+        err := errors.New("EnumTemplate.Values is not defined")
+        return nil, err
+    }
+
+    enum.Name = enumTemplate.Name
+    enum.Description = enumTemplate.Description
+    enum.Values = enumTemplate.Values
+    // fill enum value name by map key, enum value default to it's name
+    for enumName, enumValue := range enum.Values {
+        if enumValue == nil {
+            enumValue = &EnumValue{}
+            enum.Values[enumName] = enumValue
+        }
+        enumValue.Name = enumName
+        if enumValue.Value == nil {
+            enumValue.Value = enumName
+        }
+    }
+    return enum, nil
+}
+
+/**
+ * Input Object Syntax
+ * A GraphQL Input Object defines a set of input fields, the input fields are either scalars, enums,
+ * or other input objects. Input objects are only allowed as argument types.
+ */
+
+type InputObjectFields map[string]*InputObjectField
+
+type InputObjectField struct {
+    Name         string
+    Type         Type
+    Description  string
+    DefaultValue interface{}
+}
+
+type InputObjectTemplate struct {
+    Name        string
+    Description string
+    Fields      InputObjectFields
+}
+
+type InputObject struct {
+    Name        string
+    Description string
+    Fields      InputObjectFields
+}
+
+func (inputObject *InputObject) GetName() string {
+    return inputObject.Name
+}
+
+func (inputObject *InputObject) GetFields() InputObjectFields {
+    return inputObject.Fields
+}
+
+func NewInputObject(inputObjectTemplate InputObjectTemplate) (*InputObject, error) {
+    inputObject := &InputObject{}
+
+    // check input object input
+    if inputObjectTemplate.Name == "" {
+        err := errors.New("InputObjectTemplate.Name is not defined")
+        return nil, err
+    }
+    if len(inputObjectTemplate.Fields) == 0 {
+        err := errors.New("InputObjectTemplate.Fields is not defined")
+        return nil, err
+    }
+
+    inputObject.Name = inputObjectTemplate.Name
+    inputObject.Description = inputObjectTemplate.Description
+    inputObject.Fields = inputObjectTemplate.Fields
+    return inputObject, nil
+}
+
 // Object Syntax
 
 type ObjectFields map[string]*ObjectField
 
 type ObjectTemplate struct {
-    Name   string 
-    Fields ObjectFields
+    Name        string 
+    Description string
+    Fields      ObjectFields
+    Interfaces  []*Interface
+    IsTypeOf    IsTypeOfFunction
 }
 
 type Object struct {
-    Name   string
-    Fields ObjectFields
+    Name        string
+    Description string
+    Fields      ObjectFields
+    Interfaces  []*Interface
+    IsTypeOf    IsTypeOfFunction `json:"-"`
+}
+
+// check resolved data is this Object type, for Interface or Union without ResolveType
+type IsTypeOfFunction func(p IsTypeOfParams) bool
+
+type IsTypeOfParams struct {
+    // resolved data of abstract type field
+    Value interface{}
 }
 
 func (object *Object) GetName() string {
@@ -674,19 +1164,37 @@ func (object *Object) GetFields() ObjectFields {
     return object.Fields
 } 
 
+func (object *Object) GetInterfaces() []*Interface {
+    return object.Interfaces
+}
+
+func (object *Object) ImplementsInterface(targetInterface *Interface) bool {
+    for _, objectInterface := range object.Interfaces {
+        if objectInterface == targetInterface || objectInterface.Name == targetInterface.Name {
+            return true
+        }
+    }
+    return false
+}
+
 type ObjectField struct {
     Name            string               `json:name`
     Type            FieldType            `json:type`  // maybe call this returnType?
     Description     string               `json:description`
     Arguments       *Arguments           `json:arguments`    
     ResolveFunction ResolveFunction      `json:"-"`
+    // non-empty reason marks the field deprecated in introspection
+    DeprecationReason string
 }
 
 type Arguments map[string]*Argument
 
 type Argument struct {
-    Name string    `json:name` 
-    Type FieldType `json:type`
+    Name         string    `json:name` 
+    Type         FieldType `json:type`
+    Description  string
+    // applied when client omits the argument, in variable value form, e.g. 10 for `limit: Int = 10` or "MALE" for enum
+    DefaultValue interface{}
 }
 
 type ResolveFunction func(p ResolveParams) (interface{}, error)
@@ -699,6 +1207,9 @@ type ResolveParams struct {
 
     // arguments map from request
     Arguments map[string]interface{}
+
+    // schema of current request, e.g. for introspection
+    Schema *Schema
 }
 
 
@@ -711,23 +1222,155 @@ func NewObject(objectTemplate ObjectTemplate) (*Object, error) {
         return nil, err
     }
     
+    // object must provide all fields of it's interfaces
+    for _, objectInterface := range objectTemplate.Interfaces {
+        for fieldName := range objectInterface.Fields {
+            if _, ok := objectTemplate.Fields[fieldName]; !ok {
+                err := errors.New("NewObject(): Object "+objectTemplate.Name+" does not provide field "+fieldName+" of Interface "+objectInterface.Name+".")
+                return nil, err
+            }
+        }
+    }
+    
     object.Name = objectTemplate.Name
+    object.Description = objectTemplate.Description
     object.Fields = objectTemplate.Fields
+    object.Interfaces = objectTemplate.Interfaces
+    object.IsTypeOf = objectTemplate.IsTypeOf
     return object, nil
 }
 
+/**
+ * Interface Syntax
+ * Interfaces represent a list of named fields and their arguments. GraphQL objects can then implement
+ * these interfaces which requires that the object type will define all fields defined by those interfaces.
+ */
+
+// resolve Object type of Interface or Union resolved data
+type ResolveTypeFunction func(p ResolveTypeParams) *Object
+
+type ResolveTypeParams struct {
+    // resolved data of abstract type field
+    Value interface{}
+}
+
+type InterfaceTemplate struct {
+    Name        string
+    Description string
+    Fields      ObjectFields
+    ResolveType ResolveTypeFunction
+}
+
+type Interface struct {
+    Name        string
+    Description string
+    Fields      ObjectFields
+    ResolveType ResolveTypeFunction `json:"-"`
+}
+
+func (it *Interface) GetName() string {
+    return it.Name
+}
+
+func (it *Interface) GetFields() ObjectFields {
+    return it.Fields
+}
+
+func NewInterface(interfaceTemplate InterfaceTemplate) (*Interface, error) {
+    it := &Interface{}
+
+    // check interface input
+    if interfaceTemplate.Name == "" {
+        err := errors.New("InterfaceTemplate.Name is not defined")
+        return nil, err
+    }
+
+    it.Name = interfaceTemplate.Name
+    it.Description = interfaceTemplate.Description
+    it.Fields = interfaceTemplate.Fields
+    it.ResolveType = interfaceTemplate.ResolveType
+    return it, nil
+}
+
+/**
+ * Union Syntax
+ * GraphQL Unions represent an object that could be one of a list of GraphQL Object types,
+ * but provides for no guaranteed fields between those types.
+ */
+
+type UnionTemplate struct {
+    Name        string
+    Description string
+    Types       []*Object
+    ResolveType ResolveTypeFunction
+}
+
+type Union struct {
+    Name        string
+    Description string
+    Types       []*Object
+    ResolveType ResolveTypeFunction `json:"-"`
+}
+
+func (union *Union) GetName() string {
+    return union.Name
+}
+
+func (union *Union) GetTypes() []*Object {
+    return union.Types
+}
+
+func NewUnion(unionTemplate UnionTemplate) (*Union, error) {
+    union := &Union{}
+
+    // check union input
+    if unionTemplate.Name == "" {
+        err := errors.New("UnionTemplate.Name is not defined")
+        return nil, err
+    }
+    if len(unionTemplate.Types) == 0 {
+        err := errors.New("UnionTemplate.Types is not defined")
+        return nil, err
+    }
+
+    union.Name = unionTemplate.Name
+    union.Description = unionTemplate.Description
+    union.Types = unionTemplate.Types
+    union.ResolveType = unionTemplate.ResolveType
+    return union, nil
+}
+
+func isAbstractType(targetType Type) bool {
+    switch targetType.(type) {
+    case *Interface, *Union:
+        return true
+    }
+    return false
+}
+
 // Schema Syntax
 
 type SchemaTemplate struct {
     Query        *Object
     Mutation     *Object 
     Subscription *Object 
+    Directives   []*Directive
+    // types not reachable from root objects, e.g. Object implementations of Interface
+    Types        []Type
 }
 
 type Schema struct {
     Query        *Object
     Mutation     *Object 
     Subscription *Object 
+
+    // extra types from SchemaTemplate.Types
+    Types        []Type
+
+    // all named types reachable from root objects, mapped by type name
+    TypeMap      map[string]Type
+    // built-in directives and user defined directives
+    Directives   []*Directive
 }
 
 
@@ -755,6 +1398,153 @@ func (schema *Schema) GetSubscriptionObjectFields() ObjectFields {
     return schema.Subscription.Fields
 }
 
+// get directive by name, fallback to built-in directives for schema without directives
+func (schema *Schema) GetDirective(name string) *Directive {
+    if schema.Directives == nil {
+        return getSpecifiedDirective(name)
+    }
+    for _, directive := range schema.Directives {
+        if directive.Name == name {
+            return directive
+        }
+    }
+    return nil
+}
+
+// get Object types of Union, or Object types implement Interface, ordered by name
+func (schema *Schema) GetPossibleTypes(abstractType Type) []*Object {
+    switch t := abstractType.(type) {
+    case *Union:
+        return t.Types
+    case *Interface:
+        var possibleTypes []*Object
+        var typeNames     []string
+        typeMap := schema.GetTypeMap()
+        for typeName, targetType := range typeMap {
+            if object, ok := targetType.(*Object); ok && object.ImplementsInterface(t) {
+                typeNames = append(typeNames, typeName)
+            }
+        }
+        sort.Strings(typeNames)
+        for _, typeName := range typeNames {
+            possibleTypes = append(possibleTypes, typeMap[typeName].(*Object))
+        }
+        return possibleTypes
+    }
+    return nil
+}
+
+func (schema *Schema) IsPossibleType(abstractType Type, object *Object) bool {
+    for _, possibleType := range schema.GetPossibleTypes(abstractType) {
+        if possibleType == object || possibleType.Name == object.Name {
+            return true
+        }
+    }
+    return false
+}
+
+func (schema *Schema) GetTypeMap() map[string]Type {
+    if schema.TypeMap == nil {
+        schema.TypeMap = collectTypeMap(schema)
+    }
+    return schema.TypeMap
+}
+
+func (schema *Schema) GetType(name string) Type {
+    if targetType, ok := schema.GetTypeMap()[name]; ok {
+        return targetType
+    }
+    return nil
+}
+
+// build name mapped types from root objects, built-in scalars are always included
+func collectTypeMap(schema *Schema) map[string]Type {
+    typeMap := map[string]Type{
+        Int.Name    : Int,
+        Float.Name  : Float,
+        String.Name : String,
+        Bool.Name   : Bool,
+        ID.Name     : ID,
+    }
+    for _, rootObject := range []*Object{schema.Query, schema.Mutation, schema.Subscription} {
+        if rootObject != nil {
+            collectTypes(typeMap, rootObject)
+        }
+    }
+    for _, extraType := range schema.Types {
+        collectTypes(typeMap, extraType)
+    }
+    // introspection types, e.g. for fragment on __Type
+    collectTypes(typeMap, introspectionSchema)
+    for _, directive := range schema.Directives {
+        if directive.Arguments == nil {
+            continue
+        }
+        for _, argument := range *directive.Arguments {
+            collectTypes(typeMap, argument.Type)
+        }
+    }
+    return typeMap
+}
+
+func collectTypes(typeMap map[string]Type, targetType Type) {
+    switch t := targetType.(type) {
+    case *List:
+        collectTypes(typeMap, t.Payload)
+    case *NonNull:
+        collectTypes(typeMap, t.OfType)
+    case *Scalar:
+        typeMap[t.Name] = t
+    case *Enum:
+        typeMap[t.Name] = t
+    case *InputObject:
+        if _, ok := typeMap[t.Name]; ok {
+            return
+        }
+        typeMap[t.Name] = t
+        for _, inputObjectField := range t.Fields {
+            collectTypes(typeMap, inputObjectField.Type)
+        }
+    case *Object:
+        // already collected, stop here for self-referenced objects
+        if _, ok := typeMap[t.Name]; ok {
+            return
+        }
+        typeMap[t.Name] = t
+        for _, objectInterface := range t.Interfaces {
+            collectTypes(typeMap, objectInterface)
+        }
+        collectFieldsTypes(typeMap, t.Fields)
+    case *Interface:
+        if _, ok := typeMap[t.Name]; ok {
+            return
+        }
+        typeMap[t.Name] = t
+        collectFieldsTypes(typeMap, t.Fields)
+    case *Union:
+        if _, ok := typeMap[t.Name]; ok {
+            return
+        }
+        typeMap[t.Name] = t
+        for _, object := range t.Types {
+            collectTypes(typeMap, object)
+        }
+    }
+}
+
+// collect field types and argument types of Object or Interface
+func collectFieldsTypes(typeMap map[string]Type, fields ObjectFields) {
+    for _, objectField := range fields {
+        collectTypes(typeMap, objectField.Type)
+        if objectField.Arguments == nil {
+            continue
+        }
+        for _, argument := range *objectField.Arguments {
+            collectTypes(typeMap, argument.Type)
+        }
+    }
+}
+
 func NewSchema(schemaTemplate SchemaTemplate) (Schema, error) {
     schema := Schema{}
 
@@ -762,7 +1552,17 @@ func NewSchema(schemaTemplate SchemaTemplate) (Schema, error) {
     schema.Query = schemaTemplate.Query
     schema.Mutation = schemaTemplate.Mutation
     schema.Subscription = schemaTemplate.Subscription
-
+    schema.Types = schemaTemplate.Types
+    // built-in directives first, user defined directives can not override them
+    schema.Directives = append([]*Directive{}, SpecifiedDirectives...)
+    for _, directive := range schemaTemplate.Directives {
+        if getSpecifiedDirective(directive.Name) != nil {
+            err := errors.New("NewSchema(): directive @"+directive.Name+" is a built-in directive.")
+            return schema, err
+        }
+        schema.Directives = append(schema.Directives, directive)
+    }
+    schema.TypeMap = collectTypeMap(&schema)
 
     return schema, nil
 }
\ No newline at end of file
@@ -0,0 +1,237 @@
+// executor_test.go
+
+package backend
+
+import (
+    "encoding/json"
+    "errors"
+    "testing"
+)
+
+/**
+ * Execute tests
+ * variables is the JSON request variables, result is the JSON encoded Result, e.g.
+ * {"data":{"hello":"world"}} or {"data":null,"errors":[{"message":"..."}]}
+ */
+type executeTest struct {
+    name          string
+    query         string
+    variables     string
+    operationName string
+    result        string
+}
+
+func runExecuteTests(t *testing.T, schema Schema, tests []executeTest) {
+    t.Helper()
+    for _, test := range tests {
+        variables, err := DecodeVariables(test.variables)
+        if err != nil {
+            t.Errorf("%s: DecodeVariables() error: %v", test.name, err)
+            continue
+        }
+        result := Execute(Request{Schema: schema, Query: test.query, Variables: variables, OperationName: test.operationName})
+        encoded, err := json.Marshal(result)
+        if err != nil {
+            t.Errorf("%s: json.Marshal() error: %v", test.name, err)
+            continue
+        }
+        if string(encoded) != test.result {
+            t.Errorf("%s:\n    got  %s\n    want %s", test.name, encoded, test.result)
+        }
+    }
+}
+
+func newExecuteTestSchema(t *testing.T, schemaTemplate SchemaTemplate) Schema {
+    t.Helper()
+    schema, err := NewSchema(schemaTemplate)
+    if err != nil {
+        t.Fatalf("NewSchema() error: %v", err)
+    }
+    return schema
+}
+
+func TestExecuteOperationName(t *testing.T) {
+    query, _ := NewObject(ObjectTemplate{
+        Name: "Query",
+        Fields: ObjectFields{
+            "hello": &ObjectField{
+                Name: "hello",
+                Type: String,
+                ResolveFunction: func(p ResolveParams) (interface{}, error) {
+                    return "world", nil
+                },
+            },
+        },
+    })
+    mutation, _ := NewObject(ObjectTemplate{
+        Name: "Mutation",
+        Fields: ObjectFields{
+            "rename": &ObjectField{
+                Name: "rename",
+                Type: String,
+                Arguments: &Arguments{
+                    "name": &Argument{Name: "name", Type: NewNonNull(String)},
+                },
+                ResolveFunction: func(p ResolveParams) (interface{}, error) {
+                    return p.Arguments["name"], nil
+                },
+            },
+        },
+    })
+    schema := newExecuteTestSchema(t, SchemaTemplate{Query: query, Mutation: mutation})
+    runExecuteTests(t, schema, []executeTest{
+        {name: "anonymous operation", query: `{ hello }`,
+            result: `{"data":{"hello":"world"}}`},
+        {name: "single named operation without operationName", query: `query A { hello }`,
+            result: `{"data":{"hello":"world"}}`},
+        {name: "select query", query: `query A { a: hello } query B { b: hello }`, operationName: "B",
+            result: `{"data":{"b":"world"}}`},
+        {name: "select mutation", query: `query A { hello } mutation M { rename(name: "x") }`, operationName: "M",
+            result: `{"data":{"rename":"x"}}`},
+        {name: "multiple operations without operationName", query: `query A { hello } query B { hello }`,
+            result: `{"data":null,"errors":[{"message":"GetOperationDefinitionByName(): must provide operation name if query contains multiple operations."}]}`},
+        {name: "unknown operationName", query: `query A { hello }`, operationName: "C",
+            result: `{"data":null,"errors":[{"message":"GetOperationDefinitionByName(): unknown operation named 'C'."}]}`},
+    })
+}
+
+// pets are resolved as map, "kind" picks the runtime Object type
+func newAbstractTypeTestSchema(t *testing.T) Schema {
+    t.Helper()
+    var dog, cat, query *Object
+    pet, _ := NewInterface(InterfaceTemplate{
+        Name: "Pet",
+        Fields: ObjectFields{
+            "name": &ObjectField{Name: "name", Type: String},
+        },
+        ResolveType: func(p ResolveTypeParams) *Object {
+            switch p.Value.(map[string]interface{})["kind"] {
+            case "dog":
+                return dog
+            case "cat":
+                return cat
+            case "query":
+                return query
+            }
+            return nil
+        },
+    })
+    kindIs := func(kind string) IsTypeOfFunction {
+        return func(p IsTypeOfParams) bool {
+            return p.Value.(map[string]interface{})["kind"] == kind
+        }
+    }
+    dog, _ = NewObject(ObjectTemplate{
+        Name: "Dog",
+        Fields: ObjectFields{
+            "name":  &ObjectField{Name: "name", Type: String},
+            "barks": &ObjectField{Name: "barks", Type: Bool},
+        },
+        Interfaces: []*Interface{pet},
+        IsTypeOf:   kindIs("dog"),
+    })
+    cat, _ = NewObject(ObjectTemplate{
+        Name: "Cat",
+        Fields: ObjectFields{
+            "name":  &ObjectField{Name: "name", Type: String},
+            "meows": &ObjectField{Name: "meows", Type: Bool},
+        },
+        Interfaces: []*Interface{pet},
+        IsTypeOf:   kindIs("cat"),
+    })
+    animal, _ := NewUnion(UnionTemplate{Name: "Animal", Types: []*Object{dog, cat}})
+    pets := []interface{}{
+        map[string]interface{}{"kind": "dog", "name": "Odie", "barks": true},
+        map[string]interface{}{"kind": "cat", "name": "Garfield", "meows": false},
+    }
+    resolveValue := func(value interface{}) ResolveFunction {
+        return func(p ResolveParams) (interface{}, error) {
+            return value, nil
+        }
+    }
+    query, _ = NewObject(ObjectTemplate{
+        Name: "Query",
+        Fields: ObjectFields{
+            "pets":    &ObjectField{Name: "pets", Type: NewList(pet), ResolveFunction: resolveValue(pets)},
+            "animals": &ObjectField{Name: "animals", Type: NewList(animal), ResolveFunction: resolveValue(pets)},
+            "unknownPet": &ObjectField{Name: "unknownPet", Type: pet,
+                ResolveFunction: resolveValue(map[string]interface{}{"kind": "fish"})},
+            "unknownAnimal": &ObjectField{Name: "unknownAnimal", Type: animal,
+                ResolveFunction: resolveValue(map[string]interface{}{"kind": "fish"})},
+            "impossiblePet": &ObjectField{Name: "impossiblePet", Type: pet,
+                ResolveFunction: resolveValue(map[string]interface{}{"kind": "query"})},
+        },
+    })
+    return newExecuteTestSchema(t, SchemaTemplate{Query: query, Types: []Type{dog, cat}})
+}
+
+func TestExecuteAbstractType(t *testing.T) {
+    schema := newAbstractTypeTestSchema(t)
+    runExecuteTests(t, schema, []executeTest{
+        {name: "interface ResolveType", query: `{ pets { __typename name ... on Dog { barks } ... on Cat { meows } } }`,
+            result: `{"data":{"pets":[{"__typename":"Dog","barks":true,"name":"Odie"},{"__typename":"Cat","meows":false,"name":"Garfield"}]}}`},
+        {name: "union IsTypeOf", query: `{ animals { __typename ... on Dog { name barks } } }`,
+            result: `{"data":{"animals":[{"__typename":"Dog","barks":true,"name":"Odie"},{"__typename":"Cat"}]}}`},
+        {name: "fragment on interface", query: `{ animals { ...P } } fragment P on Pet { name }`,
+            result: `{"data":{"animals":[{"name":"Odie"},{"name":"Garfield"}]}}`},
+        {name: "ResolveType returns nil", query: `{ unknownPet { name } }`,
+            result: `{"data":{"unknownPet":null},"errors":[{"message":"resolveRuntimeObject(): abstract type Pet must resolve to an Object type at runtime, please check ResolveType or IsTypeOf function.","locations":[{"line":1,"column":3}],"path":["unknownPet"]}]}`},
+        {name: "no IsTypeOf matched", query: `{ unknownAnimal { __typename } }`,
+            result: `{"data":{"unknownAnimal":null},"errors":[{"message":"resolveRuntimeObject(): abstract type Animal must resolve to an Object type at runtime, please check ResolveType or IsTypeOf function.","locations":[{"line":1,"column":3}],"path":["unknownAnimal"]}]}`},
+        {name: "ResolveType returns impossible type", query: `{ impossiblePet { name } }`,
+            result: `{"data":{"impossiblePet":null},"errors":[{"message":"resolveRuntimeObject(): runtime Object type Query is not a possible type for Pet.","locations":[{"line":1,"column":3}],"path":["impossiblePet"]}]}`},
+    })
+}
+
+func TestExecuteNullPropagation(t *testing.T) {
+    resolveValue := func(value interface{}, err error) ResolveFunction {
+        return func(p ResolveParams) (interface{}, error) {
+            return value, err
+        }
+    }
+    user, _ := NewObject(ObjectTemplate{
+        Name: "User",
+        Fields: ObjectFields{
+            "name": &ObjectField{Name: "name", Type: NewNonNull(String)},
+            "nick": &ObjectField{Name: "nick", Type: String},
+            "boom": &ObjectField{Name: "boom", Type: NewNonNull(String), ResolveFunction: resolveValue(nil, errors.New("boom"))},
+        },
+    })
+    validUser  := map[string]interface{}{"name": "a", "nick": nil}
+    brokenUser := map[string]interface{}{"name": nil, "nick": "b"}
+    query, _ := NewObject(ObjectTemplate{
+        Name: "Query",
+        Fields: ObjectFields{
+            "user":           &ObjectField{Name: "user", Type: user, ResolveFunction: resolveValue(validUser, nil)},
+            "brokenUser":     &ObjectField{Name: "brokenUser", Type: user, ResolveFunction: resolveValue(brokenUser, nil)},
+            "requiredUser":   &ObjectField{Name: "requiredUser", Type: NewNonNull(user), ResolveFunction: resolveValue(brokenUser, nil)},
+            "users":          &ObjectField{Name: "users", Type: NewList(NewNonNull(user)), ResolveFunction: resolveValue([]interface{}{validUser, brokenUser}, nil)},
+            "nullableUsers":  &ObjectField{Name: "nullableUsers", Type: NewList(user), ResolveFunction: resolveValue([]interface{}{validUser, brokenUser}, nil)},
+            "items":          &ObjectField{Name: "items", Type: NewList(NewNonNull(Int)), ResolveFunction: resolveValue([]interface{}{1, nil, 3}, nil)},
+            "nullableItems":  &ObjectField{Name: "nullableItems", Type: NewList(Int), ResolveFunction: resolveValue([]interface{}{1, nil, 3}, nil)},
+            "requiredItems":  &ObjectField{Name: "requiredItems", Type: NewNonNull(NewList(NewNonNull(Int))), ResolveFunction: resolveValue([]interface{}{1, nil}, nil)},
+            "requiredString": &ObjectField{Name: "requiredString", Type: NewNonNull(String), ResolveFunction: resolveValue(nil, nil)},
+        },
+    })
+    schema := newExecuteTestSchema(t, SchemaTemplate{Query: query})
+    runExecuteTests(t, schema, []executeTest{
+        {name: "nullable field", query: `{ user { name nick } }`,
+            result: `{"data":{"user":{"name":"a","nick":null}}}`},
+        {name: "null to nullable parent", query: `{ brokenUser { nick name } user { name } }`,
+            result: `{"data":{"brokenUser":null,"user":{"name":"a"}},"errors":[{"message":"resolveField(): cannot return null for non-nullable field name.","locations":[{"line":1,"column":21}],"path":["brokenUser","name"]}]}`},
+        {name: "resolver error to nullable parent", query: `{ user { name boom } }`,
+            result: `{"data":{"user":null},"errors":[{"message":"boom","locations":[{"line":1,"column":15}],"path":["user","boom"]}]}`},
+        {name: "null to data", query: `{ user { name } requiredUser { name } }`,
+            result: `{"data":null,"errors":[{"message":"resolveField(): cannot return null for non-nullable field name.","locations":[{"line":1,"column":32}],"path":["requiredUser","name"]}]}`},
+        {name: "non-null root field", query: `{ requiredString }`,
+            result: `{"data":null,"errors":[{"message":"resolveField(): cannot return null for non-nullable field requiredString.","locations":[{"line":1,"column":3}],"path":["requiredString"]}]}`},
+        {name: "non-null list item to nullable list", query: `{ users { name } }`,
+            result: `{"data":{"users":null},"errors":[{"message":"resolveField(): cannot return null for non-nullable field name.","locations":[{"line":1,"column":11}],"path":["users",1,"name"]}]}`},
+        {name: "nullable list item", query: `{ nullableUsers { name } }`,
+            result: `{"data":{"nullableUsers":[{"name":"a"},null]},"errors":[{"message":"resolveField(): cannot return null for non-nullable field name.","locations":[{"line":1,"column":19}],"path":["nullableUsers",1,"name"]}]}`},
+        {name: "non-null scalar list item", query: `{ items nullableItems }`,
+            result: `{"data":{"items":null,"nullableItems":[1,null,3]},"errors":[{"message":"resolveSubField(): cannot return null for non-nullable field items.","locations":[{"line":1,"column":3}],"path":["items",1]}]}`},
+        {name: "non-null list", query: `{ user { name } requiredItems }`,
+            result: `{"data":null,"errors":[{"message":"resolveSubField(): cannot return null for non-nullable field requiredItems.","locations":[{"line":1,"column":17}],"path":["requiredItems",1]}]}`},
+    })
+}
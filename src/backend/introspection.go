@@ -0,0 +1,509 @@
+// introspection.go
+
+package backend
+
+import (
+    "encoding/json"
+    "sort"
+    "strings"
+)
+
+/**
+ * Introspection
+ * A GraphQL server supports introspection over its schema by the meta-fields __schema and __type
+ * on the query root, and __typename on every composite type. The schema is described by the
+ * introspection types __Schema, __Type, __Field, __InputValue, __EnumValue and __Directive,
+ * their resolvers read the Go definitions (Object, Scalar, List, Argument etc.) directly.
+ */
+
+// __TypeKind values
+const (
+    TypeKindScalar      = "SCALAR"
+    TypeKindObject      = "OBJECT"
+    TypeKindInterface   = "INTERFACE"
+    TypeKindUnion       = "UNION"
+    TypeKindEnum        = "ENUM"
+    TypeKindInputObject = "INPUT_OBJECT"
+    TypeKindList        = "LIST"
+    TypeKindNonNull     = "NON_NULL"
+)
+
+// all directive locations, include type system locations
+var directiveLocations = []string{
+    DirectiveLocationQuery,
+    DirectiveLocationMutation,
+    DirectiveLocationSubscription,
+    DirectiveLocationField,
+    DirectiveLocationFragmentDefinition,
+    DirectiveLocationFragmentSpread,
+    DirectiveLocationInlineFragment,
+    DirectiveLocationVariableDefinition,
+    DirectiveLocationSchema,
+    DirectiveLocationScalar,
+    DirectiveLocationObject,
+    DirectiveLocationFieldDefinition,
+    DirectiveLocationArgumentDefinition,
+    DirectiveLocationInterface,
+    DirectiveLocationUnion,
+    DirectiveLocationEnum,
+    DirectiveLocationEnumValue,
+    DirectiveLocationInputObject,
+    DirectiveLocationInputFieldDefinition,
+}
+
+// introspection types, built by init() since they reference each other
+var (
+    introspectionSchema            *Object
+    introspectionType              *Object
+    introspectionField             *Object
+    introspectionInputValue        *Object
+    introspectionEnumValue         *Object
+    introspectionDirective         *Object
+    introspectionTypeKind          *Enum
+    introspectionDirectiveLocation *Enum
+)
+
+// argument or input object field, resolved as __InputValue
+type inputValueDefinition struct {
+    Name         string
+    Description  string
+    Type         Type
+    DefaultValue interface{}
+}
+
+func init() {
+    introspectionTypeKind = newIntrospectionEnum("__TypeKind", "An enum describing what kind of type a given `__Type` is.", []string{
+        TypeKindScalar, TypeKindObject, TypeKindInterface, TypeKindUnion, TypeKindEnum, TypeKindInputObject, TypeKindList, TypeKindNonNull,
+    })
+    introspectionDirectiveLocation = newIntrospectionEnum("__DirectiveLocation", "A Directive can be adjacent to many parts of the GraphQL language, a __DirectiveLocation describes one such possible adjacencies.", directiveLocations)
+
+    introspectionSchema     = &Object{Name: "__Schema", Description: "A GraphQL Schema defines the capabilities of a GraphQL server. It exposes all available types and directives on the server, as well as the entry points for query, mutation, and subscription operations."}
+    introspectionType       = &Object{Name: "__Type", Description: "The fundamental unit of any GraphQL Schema is the type. There are many kinds of types in GraphQL as represented by the `__TypeKind` enum."}
+    introspectionField      = &Object{Name: "__Field", Description: "Object and Interface types are described by a list of Fields, each of which has a name, potentially a list of arguments, and a return type."}
+    introspectionInputValue = &Object{Name: "__InputValue", Description: "Arguments provided to Fields or Directives and the input fields of an InputObject are represented as Input Values which describe their type and optionally a default value."}
+    introspectionEnumValue  = &Object{Name: "__EnumValue", Description: "One possible value for a given Enum. Enum values are unique values, not a placeholder for a string or numeric value."}
+    introspectionDirective  = &Object{Name: "__Directive", Description: "A Directive provides a way to describe alternate runtime execution and type validation behavior in a GraphQL document."}
+
+    nonNullListOf := func(t Type) *NonNull {
+        return NewNonNull(NewList(NewNonNull(t)))
+    }
+    includeDeprecated := &Arguments{
+        "includeDeprecated": &Argument{Name: "includeDeprecated", Type: Bool, DefaultValue: false},
+    }
+
+    introspectionSchema.Fields = ObjectFields{
+        "description": &ObjectField{Name: "description", Type: String, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return nil, nil
+        }},
+        "types": &ObjectField{Name: "types", Type: nonNullListOf(introspectionType), Description: "A list of all types supported by this server.", ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return getSortedTypes(p.Context.(*Schema).GetTypeMap()), nil
+        }},
+        "queryType": &ObjectField{Name: "queryType", Type: NewNonNull(introspectionType), Description: "The type that query operations will be rooted at.", ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return p.Context.(*Schema).Query, nil
+        }},
+        "mutationType": &ObjectField{Name: "mutationType", Type: introspectionType, Description: "If this server supports mutation, the type that mutation operations will be rooted at.", ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return p.Context.(*Schema).Mutation, nil
+        }},
+        "subscriptionType": &ObjectField{Name: "subscriptionType", Type: introspectionType, Description: "If this server support subscription, the type that subscription operations will be rooted at.", ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return p.Context.(*Schema).Subscription, nil
+        }},
+        "directives": &ObjectField{Name: "directives", Type: nonNullListOf(introspectionDirective), Description: "A list of all directives supported by this server.", ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            schema := p.Context.(*Schema)
+            if schema.Directives == nil {
+                return SpecifiedDirectives, nil
+            }
+            return schema.Directives, nil
+        }},
+    }
+
+    introspectionType.Fields = ObjectFields{
+        "kind": &ObjectField{Name: "kind", Type: NewNonNull(introspectionTypeKind), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return getTypeKind(p.Context.(Type)), nil
+        }},
+        "name": &ObjectField{Name: "name", Type: String, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            switch p.Context.(type) {
+            case *List, *NonNull:
+                return nil, nil
+            }
+            return p.Context.(Type).GetName(), nil
+        }},
+        "description": &ObjectField{Name: "description", Type: String, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return getDescription(p.Context), nil
+        }},
+        "specifiedByURL": &ObjectField{Name: "specifiedByURL", Type: String, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return nil, nil
+        }},
+        "fields": &ObjectField{Name: "fields", Type: NewList(NewNonNull(introspectionField)), Arguments: includeDeprecated, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            var fields ObjectFields
+            switch t := p.Context.(type) {
+            case *Object:
+                fields = t.Fields
+            case *Interface:
+                fields = t.Fields
+            default:
+                return nil, nil
+            }
+            includeDeprecated, _ := p.Arguments["includeDeprecated"].(bool)
+            var objectFields []*ObjectField
+            for _, objectField := range getSortedFields(fields) {
+                if objectField.DeprecationReason == "" || includeDeprecated {
+                    objectFields = append(objectFields, objectField)
+                }
+            }
+            return objectFields, nil
+        }},
+        "interfaces": &ObjectField{Name: "interfaces", Type: NewList(NewNonNull(introspectionType)), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            switch t := p.Context.(type) {
+            case *Object:
+                return append([]*Interface{}, t.Interfaces...), nil
+            case *Interface:
+                return []*Interface{}, nil
+            }
+            return nil, nil
+        }},
+        "possibleTypes": &ObjectField{Name: "possibleTypes", Type: NewList(NewNonNull(introspectionType)), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            if targetType := p.Context.(Type); isAbstractType(targetType) {
+                return p.Schema.GetPossibleTypes(targetType), nil
+            }
+            return nil, nil
+        }},
+        "enumValues": &ObjectField{Name: "enumValues", Type: NewList(NewNonNull(introspectionEnumValue)), Arguments: includeDeprecated, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            enum, ok := p.Context.(*Enum)
+            if !ok {
+                return nil, nil
+            }
+            includeDeprecated, _ := p.Arguments["includeDeprecated"].(bool)
+            var enumValues []*EnumValue
+            for _, enumValue := range getSortedEnumValues(enum.Values) {
+                if enumValue.DeprecationReason == "" || includeDeprecated {
+                    enumValues = append(enumValues, enumValue)
+                }
+            }
+            return enumValues, nil
+        }},
+        "inputFields": &ObjectField{Name: "inputFields", Type: NewList(NewNonNull(introspectionInputValue)), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            if inputObject, ok := p.Context.(*InputObject); ok {
+                return getInputFieldDefinitions(inputObject.Fields), nil
+            }
+            return nil, nil
+        }},
+        "ofType": &ObjectField{Name: "ofType", Type: introspectionType, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            switch t := p.Context.(type) {
+            case *List:
+                return t.Payload, nil
+            case *NonNull:
+                return t.OfType, nil
+            }
+            return nil, nil
+        }},
+    }
+
+    introspectionField.Fields = ObjectFields{
+        "name": &ObjectField{Name: "name", Type: NewNonNull(String), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return p.Context.(*ObjectField).Name, nil
+        }},
+        "description": &ObjectField{Name: "description", Type: String, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return getDescription(p.Context), nil
+        }},
+        "args": &ObjectField{Name: "args", Type: nonNullListOf(introspectionInputValue), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return getArgumentDefinitions(p.Context.(*ObjectField).Arguments), nil
+        }},
+        "type": &ObjectField{Name: "type", Type: NewNonNull(introspectionType), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return p.Context.(*ObjectField).Type, nil
+        }},
+        "isDeprecated": &ObjectField{Name: "isDeprecated", Type: NewNonNull(Bool), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return p.Context.(*ObjectField).DeprecationReason != "", nil
+        }},
+        "deprecationReason": &ObjectField{Name: "deprecationReason", Type: String, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            if reason := p.Context.(*ObjectField).DeprecationReason; reason != "" {
+                return reason, nil
+            }
+            return nil, nil
+        }},
+    }
+
+    introspectionInputValue.Fields = ObjectFields{
+        "name": &ObjectField{Name: "name", Type: NewNonNull(String), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return p.Context.(*inputValueDefinition).Name, nil
+        }},
+        "description": &ObjectField{Name: "description", Type: String, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return getDescription(p.Context), nil
+        }},
+        "type": &ObjectField{Name: "type", Type: NewNonNull(introspectionType), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return p.Context.(*inputValueDefinition).Type, nil
+        }},
+        "defaultValue": &ObjectField{Name: "defaultValue", Type: String, Description: "A GraphQL-formatted string representing the default value for this input value.", ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            inputValue := p.Context.(*inputValueDefinition)
+            if inputValue.DefaultValue == nil {
+                return nil, nil
+            }
+            return printValueLiteral(inputValue.DefaultValue, inputValue.Type), nil
+        }},
+        "isDeprecated": &ObjectField{Name: "isDeprecated", Type: NewNonNull(Bool), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return false, nil
+        }},
+        "deprecationReason": &ObjectField{Name: "deprecationReason", Type: String, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return nil, nil
+        }},
+    }
+
+    introspectionEnumValue.Fields = ObjectFields{
+        "name": &ObjectField{Name: "name", Type: NewNonNull(String), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return p.Context.(*EnumValue).Name, nil
+        }},
+        "description": &ObjectField{Name: "description", Type: String, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return getDescription(p.Context), nil
+        }},
+        "isDeprecated": &ObjectField{Name: "isDeprecated", Type: NewNonNull(Bool), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return p.Context.(*EnumValue).DeprecationReason != "", nil
+        }},
+        "deprecationReason": &ObjectField{Name: "deprecationReason", Type: String, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            if reason := p.Context.(*EnumValue).DeprecationReason; reason != "" {
+                return reason, nil
+            }
+            return nil, nil
+        }},
+    }
+
+    introspectionDirective.Fields = ObjectFields{
+        "name": &ObjectField{Name: "name", Type: NewNonNull(String), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return p.Context.(*Directive).Name, nil
+        }},
+        "description": &ObjectField{Name: "description", Type: String, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return getDescription(p.Context), nil
+        }},
+        "isRepeatable": &ObjectField{Name: "isRepeatable", Type: NewNonNull(Bool), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return false, nil
+        }},
+        "locations": &ObjectField{Name: "locations", Type: nonNullListOf(introspectionDirectiveLocation), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return append([]string{}, p.Context.(*Directive).Locations...), nil
+        }},
+        "args": &ObjectField{Name: "args", Type: nonNullListOf(introspectionInputValue), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+            return getArgumentDefinitions(p.Context.(*Directive).Arguments), nil
+        }},
+    }
+}
+
+func newIntrospectionEnum(name string, description string, valueNames []string) *Enum {
+    values := make(EnumValues, len(valueNames))
+    for _, valueName := range valueNames {
+        values[valueName] = &EnumValue{Name: valueName, Value: valueName}
+    }
+    return &Enum{Name: name, Description: description, Values: values}
+}
+
+/**
+ * get field definition by name from parentType, include introspection meta-fields.
+ * __typename is available on every composite type, __schema and __type only on the query root.
+ */
+func getFieldDefinition(schema *Schema, parentType Type, fieldName string) *ObjectField {
+    switch fieldName {
+    case "__typename":
+        if isCompositeType(parentType) {
+            return &ObjectField{Name: "__typename", Type: NewNonNull(String), Description: "The name of the current Object type at runtime.", ResolveFunction: func(p ResolveParams) (interface{}, error) {
+                return parentType.GetName(), nil
+            }}
+        }
+    case "__schema":
+        if schema != nil && schema.Query != nil && parentType == Type(schema.Query) {
+            return &ObjectField{Name: "__schema", Type: NewNonNull(introspectionSchema), Description: "Access the current type schema of this server.", ResolveFunction: func(p ResolveParams) (interface{}, error) {
+                return schema, nil
+            }}
+        }
+    case "__type":
+        if schema != nil && schema.Query != nil && parentType == Type(schema.Query) {
+            arguments := &Arguments{"name": &Argument{Name: "name", Type: NewNonNull(String)}}
+            return &ObjectField{Name: "__type", Type: introspectionType, Arguments: arguments, Description: "Request the type information of a single type.", ResolveFunction: func(p ResolveParams) (interface{}, error) {
+                name, _ := p.Arguments["name"].(string)
+                if targetType := schema.GetType(name); targetType != nil {
+                    return targetType, nil
+                }
+                return nil, nil
+            }}
+        }
+    }
+    return getObjectField(parentType, fieldName)
+}
+
+func getTypeKind(targetType Type) string {
+    switch targetType.(type) {
+    case *Scalar:
+        return TypeKindScalar
+    case *Object:
+        return TypeKindObject
+    case *Interface:
+        return TypeKindInterface
+    case *Union:
+        return TypeKindUnion
+    case *Enum:
+        return TypeKindEnum
+    case *InputObject:
+        return TypeKindInputObject
+    case *List:
+        return TypeKindList
+    case *NonNull:
+        return TypeKindNonNull
+    }
+    return ""
+}
+
+// get Description of schema definition, nil for empty description
+func getDescription(definition interface{}) interface{} {
+    var description string
+    switch t := definition.(type) {
+    case *Scalar:
+        description = t.Description
+    case *Object:
+        description = t.Description
+    case *Interface:
+        description = t.Description
+    case *Union:
+        description = t.Description
+    case *Enum:
+        description = t.Description
+    case *InputObject:
+        description = t.Description
+    case *ObjectField:
+        description = t.Description
+    case *EnumValue:
+        description = t.Description
+    case *Directive:
+        description = t.Description
+    case *inputValueDefinition:
+        description = t.Description
+    }
+    if description == "" {
+        return nil
+    }
+    return description
+}
+
+// get types ordered by name
+func getSortedTypes(typeMap map[string]Type) []Type {
+    var typeNames []string
+    for typeName := range typeMap {
+        typeNames = append(typeNames, typeName)
+    }
+    sort.Strings(typeNames)
+    types := make([]Type, 0, len(typeNames))
+    for _, typeName := range typeNames {
+        types = append(types, typeMap[typeName])
+    }
+    return types
+}
+
+// get fields ordered by name
+func getSortedFields(fields ObjectFields) []*ObjectField {
+    var fieldNames []string
+    for fieldName := range fields {
+        fieldNames = append(fieldNames, fieldName)
+    }
+    sort.Strings(fieldNames)
+    objectFields := make([]*ObjectField, 0, len(fieldNames))
+    for _, fieldName := range fieldNames {
+        objectFields = append(objectFields, fields[fieldName])
+    }
+    return objectFields
+}
+
+// get enum values ordered by name
+func getSortedEnumValues(values EnumValues) []*EnumValue {
+    var valueNames []string
+    for valueName := range values {
+        valueNames = append(valueNames, valueName)
+    }
+    sort.Strings(valueNames)
+    enumValues := make([]*EnumValue, 0, len(valueNames))
+    for _, valueName := range valueNames {
+        enumValues = append(enumValues, values[valueName])
+    }
+    return enumValues
+}
+
+// get arguments as __InputValue, ordered by name
+func getArgumentDefinitions(arguments *Arguments) []*inputValueDefinition {
+    inputValues := []*inputValueDefinition{}
+    if arguments == nil {
+        return inputValues
+    }
+    var argumentNames []string
+    for argumentName := range *arguments {
+        argumentNames = append(argumentNames, argumentName)
+    }
+    sort.Strings(argumentNames)
+    for _, argumentName := range argumentNames {
+        argument := (*arguments)[argumentName]
+        inputValues = append(inputValues, &inputValueDefinition{argumentName, argument.Description, argument.Type, argument.DefaultValue})
+    }
+    return inputValues
+}
+
+// get input object fields as __InputValue, ordered by name
+func getInputFieldDefinitions(fields InputObjectFields) []*inputValueDefinition {
+    var fieldNames []string
+    for fieldName := range fields {
+        fieldNames = append(fieldNames, fieldName)
+    }
+    sort.Strings(fieldNames)
+    inputValues := make([]*inputValueDefinition, 0, len(fieldNames))
+    for _, fieldName := range fieldNames {
+        field := fields[fieldName]
+        inputValues = append(inputValues, &inputValueDefinition{fieldName, field.Description, field.Type, field.DefaultValue})
+    }
+    return inputValues
+}
+
+/**
+ * print input value in variable value form as GraphQL literal, e.g.
+ * []interface{}{float64(1), float64(2)} of [Int] => [1, 2]
+ * "MALE" of enum Gender => MALE
+ * map[string]interface{}{"name": "Tom"} of input UserInput => {name: "Tom"}
+ */
+func printValueLiteral(value interface{}, valueType Type) string {
+    if value == nil {
+        return "null"
+    }
+    switch t := valueType.(type) {
+    case *NonNull:
+        return printValueLiteral(value, t.OfType)
+    case *List:
+        items, ok := value.([]interface{})
+        if !ok {
+            return printValueLiteral(value, t.Payload)
+        }
+        var literals []string
+        for _, item := range items {
+            literals = append(literals, printValueLiteral(item, t.Payload))
+        }
+        return "[" + strings.Join(literals, ", ") + "]"
+    case *InputObject:
+        fields, ok := value.(map[string]interface{})
+        if !ok {
+            break
+        }
+        var literals []string
+        for _, field := range getInputFieldDefinitions(t.Fields) {
+            if fieldValue, ok := fields[field.Name]; ok {
+                literals = append(literals, field.Name + ": " + printValueLiteral(fieldValue, field.Type))
+            }
+        }
+        return "{" + strings.Join(literals, ", ") + "}"
+    case *Enum:
+        if enumName, ok := value.(string); ok {
+            if _, ok := t.Values[enumName]; ok {
+                return enumName
+            }
+        }
+        if enumName, ok := t.Serialize(value); ok {
+            return enumName
+        }
+    }
+    if stringValue, ok := value.(string); ok {
+        return printStringLiteral(stringValue)
+    }
+    literal, err := json.Marshal(value)
+    if err != nil {
+        return "null"
+    }
+    return string(literal)
+}
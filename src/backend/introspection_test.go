@@ -0,0 +1,129 @@
+// introspection_test.go
+
+package backend
+
+import (
+    "testing"
+)
+
+func newIntrospectionTestSchema(t *testing.T) Schema {
+    t.Helper()
+    node, _ := NewInterface(InterfaceTemplate{
+        Name:        "Node",
+        Description: "Object with id",
+        Fields: ObjectFields{
+            "id": &ObjectField{Name: "id", Type: NewNonNull(ID)},
+        },
+        ResolveType: func(p ResolveTypeParams) *Object {
+            return nil
+        },
+    })
+    color, _ := NewEnum(EnumTemplate{
+        Name: "Color",
+        Values: EnumValues{
+            "RED":  &EnumValue{Value: 1, Description: "red"},
+            "BLUE": &EnumValue{Value: 2, DeprecationReason: "use RED"},
+        },
+    })
+    filter, _ := NewInputObject(InputObjectTemplate{
+        Name: "Filter",
+        Fields: InputObjectFields{
+            "color": &InputObjectField{Name: "color", Type: color, DefaultValue: 1},
+        },
+    })
+    user, _ := NewObject(ObjectTemplate{
+        Name:        "User",
+        Description: "A user",
+        Fields: ObjectFields{
+            "id":      &ObjectField{Name: "id", Type: NewNonNull(ID)},
+            "name":    &ObjectField{Name: "name", Type: String, Description: "Full name"},
+            "nick":    &ObjectField{Name: "nick", Type: String, DeprecationReason: "use name"},
+            "friends": &ObjectField{Name: "friends", Type: NewList(NewNonNull(NewList(String))), Arguments: &Arguments{
+                "first":  &Argument{Name: "first", Type: Int, Description: "count", DefaultValue: 10},
+                "filter": &Argument{Name: "filter", Type: filter},
+            }},
+        },
+        Interfaces: []*Interface{node},
+    })
+    search, _ := NewUnion(UnionTemplate{Name: "Search", Types: []*Object{user}})
+    query, _ := NewObject(ObjectTemplate{
+        Name: "Query",
+        Fields: ObjectFields{
+            "user": &ObjectField{Name: "user", Type: user, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+                return map[string]interface{}{"id": "1"}, nil
+            }},
+            "search": &ObjectField{Name: "search", Type: NewList(search)},
+        },
+    })
+    return newExecuteTestSchema(t, SchemaTemplate{Query: query})
+}
+
+func TestExecuteIntrospection(t *testing.T) {
+    schema := newIntrospectionTestSchema(t)
+    runExecuteTests(t, schema, []executeTest{
+        {name: "__typename", query: `{ __typename user { __typename id } }`,
+            result: `{"data":{"__typename":"Query","user":{"__typename":"User","id":"1"}}}`},
+        {name: "__schema root types", query: `{ __schema { queryType { name } mutationType { name } subscriptionType { name } } }`,
+            result: `{"data":{"__schema":{"mutationType":null,"queryType":{"name":"Query"},"subscriptionType":null}}}`},
+        {name: "__schema types", query: `{ __schema { types { name kind } } }`,
+            result: `{"data":{"__schema":{"types":[{"kind":"SCALAR","name":"Boolean"},{"kind":"ENUM","name":"Color"},{"kind":"INPUT_OBJECT","name":"Filter"},{"kind":"SCALAR","name":"Float"},{"kind":"SCALAR","name":"ID"},{"kind":"SCALAR","name":"Int"},{"kind":"INTERFACE","name":"Node"},{"kind":"OBJECT","name":"Query"},{"kind":"UNION","name":"Search"},{"kind":"SCALAR","name":"String"},{"kind":"OBJECT","name":"User"},{"kind":"OBJECT","name":"__Directive"},{"kind":"ENUM","name":"__DirectiveLocation"},{"kind":"OBJECT","name":"__EnumValue"},{"kind":"OBJECT","name":"__Field"},{"kind":"OBJECT","name":"__InputValue"},{"kind":"OBJECT","name":"__Schema"},{"kind":"OBJECT","name":"__Type"},{"kind":"ENUM","name":"__TypeKind"}]}}}`},
+        {name: "__schema directives", query: `{ __schema { directives { name locations args { name type { kind ofType { name } } defaultValue } } } }`,
+            result: `{"data":{"__schema":{"directives":[{"args":[{"defaultValue":null,"name":"if","type":{"kind":"NON_NULL","ofType":{"name":"Boolean"}}}],"locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"name":"include"},{"args":[{"defaultValue":null,"name":"if","type":{"kind":"NON_NULL","ofType":{"name":"Boolean"}}}],"locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"name":"skip"},{"args":[{"defaultValue":"\"No longer supported\"","name":"reason","type":{"kind":"SCALAR","ofType":null}}],"locations":["FIELD_DEFINITION","ENUM_VALUE"],"name":"deprecated"}]}}}`},
+        {name: "object", query: `{ __type(name: "User") { kind name description interfaces { name } fields { name description isDeprecated deprecationReason } } }`,
+            result: `{"data":{"__type":{"description":"A user","fields":[{"deprecationReason":null,"description":null,"isDeprecated":false,"name":"friends"},{"deprecationReason":null,"description":null,"isDeprecated":false,"name":"id"},{"deprecationReason":null,"description":"Full name","isDeprecated":false,"name":"name"}],"interfaces":[{"name":"Node"}],"kind":"OBJECT","name":"User"}}}`},
+        {name: "deprecated fields", query: `{ __type(name: "User") { fields(includeDeprecated: true) { name isDeprecated deprecationReason } } }`,
+            result: `{"data":{"__type":{"fields":[{"deprecationReason":null,"isDeprecated":false,"name":"friends"},{"deprecationReason":null,"isDeprecated":false,"name":"id"},{"deprecationReason":null,"isDeprecated":false,"name":"name"},{"deprecationReason":"use name","isDeprecated":true,"name":"nick"}]}}}`},
+        {name: "type wrappers and arguments", query: `{ __type(name: "User") { fields { name args { name description defaultValue type { name } } type { kind name ofType { kind name ofType { kind name ofType { kind name } } } } } } }`,
+            result: `{"data":{"__type":{"fields":[{"args":[{"defaultValue":null,"description":null,"name":"filter","type":{"name":"Filter"}},{"defaultValue":"10","description":"count","name":"first","type":{"name":"Int"}}],"name":"friends","type":{"kind":"LIST","name":null,"ofType":{"kind":"NON_NULL","name":null,"ofType":{"kind":"LIST","name":null,"ofType":{"kind":"SCALAR","name":"String"}}}}},{"args":[],"name":"id","type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"ID","ofType":null}}},{"args":[],"name":"name","type":{"kind":"SCALAR","name":"String","ofType":null}}]}}}`},
+        {name: "interface and union", query: `{ node: __type(name: "Node") { kind description possibleTypes { name } } search: __type(name: "Search") { kind possibleTypes { name } } }`,
+            result: `{"data":{"node":{"description":"Object with id","kind":"INTERFACE","possibleTypes":[{"name":"User"}]},"search":{"kind":"UNION","possibleTypes":[{"name":"User"}]}}}`},
+        {name: "enum", query: `{ __type(name: "Color") { kind enumValues(includeDeprecated: true) { name description isDeprecated deprecationReason } } }`,
+            result: `{"data":{"__type":{"enumValues":[{"deprecationReason":"use RED","description":null,"isDeprecated":true,"name":"BLUE"},{"deprecationReason":null,"description":"red","isDeprecated":false,"name":"RED"}],"kind":"ENUM"}}}`},
+        {name: "input object", query: `{ __type(name: "Filter") { kind inputFields { name defaultValue type { name } } } }`,
+            result: `{"data":{"__type":{"inputFields":[{"defaultValue":"RED","name":"color","type":{"name":"Color"}}],"kind":"INPUT_OBJECT"}}}`},
+        {name: "scalar", query: `{ __type(name: "ID") { kind name fields { name } } }`,
+            result: `{"data":{"__type":{"fields":null,"kind":"SCALAR","name":"ID"}}}`},
+        {name: "unknown type", query: `{ __type(name: "Unknown") { name } }`,
+            result: `{"data":{"__type":null}}`},
+        {name: "__schema on non-root type", query: `{ user { __schema { queryType { name } } } }`,
+            result: `{"data":null,"errors":[{"message":"Cannot query field \"__schema\" on type \"User\".","locations":[{"line":1,"column":10}]}]}`},
+    })
+}
+
+// introspection query sent by GraphiQL and other tools
+var introspectionTestQuery = `
+query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+    directives { name description locations args { ...InputValue } }
+  }
+}
+fragment FullType on __Type {
+  kind name description
+  fields(includeDeprecated: true) { name description args { ...InputValue } type { ...TypeRef } isDeprecated deprecationReason }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) { name description isDeprecated deprecationReason }
+  possibleTypes { ...TypeRef }
+}
+fragment InputValue on __InputValue { name description type { ...TypeRef } defaultValue }
+fragment TypeRef on __Type {
+  kind name
+  ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name ofType { kind name } } } } } } }
+}
+`
+
+func TestExecuteIntrospectionQuery(t *testing.T) {
+    result := Execute(Request{Schema: newIntrospectionTestSchema(t), Query: introspectionTestQuery})
+    if len(result.Errors) != 0 {
+        t.Fatalf("Execute() errors: %v", result.Errors[0].Message)
+    }
+    data, _ := result.Data.(map[string]interface{})
+    introspectionSchema, _ := data["__schema"].(map[string]interface{})
+    if types, _ := introspectionSchema["types"].([]interface{}); len(types) != 19 {
+        t.Errorf("__schema.types has %d types, want 19", len(types))
+    }
+}
@@ -0,0 +1,206 @@
+// printer.go
+
+package backend
+
+import (
+    "encoding/json"
+    "sort"
+    "strings"
+)
+
+/**
+ * PrintSchema
+ * print Schema as canonical SDL (schema definition language) text. Directives and types are ordered
+ * by name, fields, arguments and enum values are ordered by name, built-in scalars, built-in directives
+ * and introspection types are omitted. The schema definition is only printed when root types are not
+ * named Query, Mutation and Subscription. e.g.
+ *
+ *     type Query {
+ *       user(id: ID!): User
+ *     }
+ */
+func PrintSchema(schema Schema) string {
+    var blocks []string
+    if schemaDefinition := printSchemaDefinition(&schema); schemaDefinition != "" {
+        blocks = append(blocks, schemaDefinition)
+    }
+    directives := append([]*Directive{}, schema.Directives...)
+    sort.Slice(directives, func(i, j int) bool {
+        return directives[i].Name < directives[j].Name
+    })
+    for _, directive := range directives {
+        if getSpecifiedDirective(directive.Name) == nil {
+            blocks = append(blocks, printDirectiveDefinition(directive))
+        }
+    }
+    for _, namedType := range getSortedTypes(schema.GetTypeMap()) {
+        typeName := namedType.GetName()
+        if strings.HasPrefix(typeName, "__") || builtInScalars[typeName] != nil {
+            continue
+        }
+        blocks = append(blocks, PrintType(namedType))
+    }
+    if len(blocks) == 0 {
+        return ""
+    }
+    return strings.Join(blocks, "\n\n") + "\n"
+}
+
+// print named type definition as SDL, e.g. "scalar Date"
+func PrintType(namedType Type) string {
+    switch t := namedType.(type) {
+    case *Scalar:
+        return printDescription(t.Description, "") + "scalar " + t.Name
+    case *Object:
+        var implements string
+        if len(t.Interfaces) > 0 {
+            var interfaceNames []string
+            for _, objectInterface := range t.Interfaces {
+                interfaceNames = append(interfaceNames, objectInterface.Name)
+            }
+            implements = " implements " + strings.Join(interfaceNames, " & ")
+        }
+        return printDescription(t.Description, "") + "type " + t.Name + implements + printFields(t.Fields)
+    case *Interface:
+        return printDescription(t.Description, "") + "interface " + t.Name + printFields(t.Fields)
+    case *Union:
+        var typeNames []string
+        for _, object := range t.Types {
+            typeNames = append(typeNames, object.Name)
+        }
+        return printDescription(t.Description, "") + "union " + t.Name + " = " + strings.Join(typeNames, " | ")
+    case *Enum:
+        var lines []string
+        for _, enumValue := range getSortedEnumValues(t.Values) {
+            lines = append(lines, printDescription(enumValue.Description, "  ") + "  " + enumValue.Name + printDeprecated(enumValue.DeprecationReason))
+        }
+        return printDescription(t.Description, "") + "enum " + t.Name + printBlock(lines)
+    case *InputObject:
+        var lines []string
+        for _, inputValue := range getInputFieldDefinitions(t.Fields) {
+            lines = append(lines, printDescription(inputValue.Description, "  ") + "  " + printInputValue(inputValue))
+        }
+        return printDescription(t.Description, "") + "input " + t.Name + printBlock(lines)
+    }
+    return ""
+}
+
+// print schema definition, empty if root types use default names
+func printSchemaDefinition(schema *Schema) string {
+    rootTypes := []struct {
+        operationTypeName string
+        defaultName       string
+        object           *Object
+    }{
+        {"query", "Query", schema.Query},
+        {"mutation", "Mutation", schema.Mutation},
+        {"subscription", "Subscription", schema.Subscription},
+    }
+    var isDefault = true
+    var lines []string
+    for _, rootType := range rootTypes {
+        if rootType.object == nil {
+            continue
+        }
+        isDefault = isDefault && rootType.object.Name == rootType.defaultName
+        lines = append(lines, "  " + rootType.operationTypeName + ": " + rootType.object.Name)
+    }
+    if isDefault {
+        return ""
+    }
+    return "schema" + printBlock(lines)
+}
+
+func printDirectiveDefinition(directive *Directive) string {
+    return printDescription(directive.Description, "") + "directive @" + directive.Name + printArguments(directive.Arguments, "") + " on " + strings.Join(directive.Locations, " | ")
+}
+
+// print fields of Object or Interface as block
+func printFields(fields ObjectFields) string {
+    var lines []string
+    for _, objectField := range getSortedFields(fields) {
+        line := "  " + objectField.Name + printArguments(objectField.Arguments, "  ") + ": " + objectField.Type.GetName() + printDeprecated(objectField.DeprecationReason)
+        lines = append(lines, printDescription(objectField.Description, "  ") + line)
+    }
+    return printBlock(lines)
+}
+
+// print arguments in one line, or one argument per line if any argument has description
+func printArguments(arguments *Arguments, indentation string) string {
+    inputValues := getArgumentDefinitions(arguments)
+    if len(inputValues) == 0 {
+        return ""
+    }
+    var hasDescription bool
+    for _, inputValue := range inputValues {
+        hasDescription = hasDescription || inputValue.Description != ""
+    }
+    var printed []string
+    if !hasDescription {
+        for _, inputValue := range inputValues {
+            printed = append(printed, printInputValue(inputValue))
+        }
+        return "(" + strings.Join(printed, ", ") + ")"
+    }
+    for _, inputValue := range inputValues {
+        printed = append(printed, printDescription(inputValue.Description, indentation + "  ") + indentation + "  " + printInputValue(inputValue))
+    }
+    return "(\n" + strings.Join(printed, "\n") + "\n" + indentation + ")"
+}
+
+// print argument or input field with default value, e.g. "limit: Int = 10"
+func printInputValue(inputValue *inputValueDefinition) string {
+    printed := inputValue.Name + ": " + inputValue.Type.GetName()
+    if inputValue.DefaultValue != nil {
+        printed += " = " + printValueLiteral(inputValue.DefaultValue, inputValue.Type)
+    }
+    return printed
+}
+
+func printDeprecated(reason string) string {
+    if reason == "" {
+        return ""
+    }
+    if reason == DefaultDeprecationReason {
+        return " @deprecated"
+    }
+    return " @deprecated(reason: " + printStringLiteral(reason) + ")"
+}
+
+// print description above definition as block string, multi-line block string if description has line terminators
+func printDescription(description string, indentation string) string {
+    if description == "" {
+        return ""
+    }
+    description = strings.ReplaceAll(description, "\"\"\"", "\\\"\"\"")
+    if !strings.ContainsAny(description, "\r\n") && !strings.HasSuffix(description, "\"") && !strings.HasSuffix(description, "\\") {
+        return indentation + "\"\"\"" + description + "\"\"\"\n"
+    }
+    lines := strings.Split(strings.ReplaceAll(description, "\r\n", "\n"), "\n")
+    var printed strings.Builder
+    printed.WriteString(indentation + "\"\"\"\n")
+    for _, line := range lines {
+        if line != "" {
+            printed.WriteString(indentation + line)
+        }
+        printed.WriteString("\n")
+    }
+    printed.WriteString(indentation + "\"\"\"\n")
+    return printed.String()
+}
+
+// print string as GraphQL string literal, keep "<", ">" and "&" unescaped
+func printStringLiteral(value string) string {
+    var literal strings.Builder
+    encoder := json.NewEncoder(&literal)
+    encoder.SetEscapeHTML(false)
+    encoder.Encode(value)
+    return strings.TrimSuffix(literal.String(), "\n")
+}
+
+func printBlock(lines []string) string {
+    if len(lines) == 0 {
+        return ""
+    }
+    return " {\n" + strings.Join(lines, "\n") + "\n}"
+}
@@ -0,0 +1,99 @@
+// printer_test.go
+
+package backend
+
+import (
+    "testing"
+)
+
+var printerTestSDL = `
+schema { query: Root mutation: Change }
+directive @tag(name: String!) on FIELD_DEFINITION | OBJECT
+"Node with id" interface Node { id: ID! }
+type Root { node(id: ID!): Node users(first: Int = 10, kind: Kind = ADMIN, filter: Filter = {name: "a", kinds: [USER]}): [User!]! search(text: String): [Result] }
+type Change { rename(id: ID!, name: String!): User }
+type User implements Node { id: ID! name: String @deprecated(reason: "use fullName") fullName: String kind: Kind }
+type Group implements Node { id: ID! members: [User] }
+union Result = User | Group
+enum Kind { USER ADMIN @deprecated }
+input Filter { name: String kinds: [Kind!] }
+scalar Date
+`
+
+// canonical SDL of printerTestSDL, types and fields are ordered by name
+var printerTestPrinted = `schema {
+  query: Root
+  mutation: Change
+}
+
+directive @tag(name: String!) on FIELD_DEFINITION | OBJECT
+
+type Change {
+  rename(id: ID!, name: String!): User
+}
+
+scalar Date
+
+input Filter {
+  kinds: [Kind!]
+  name: String
+}
+
+type Group implements Node {
+  id: ID!
+  members: [User]
+}
+
+enum Kind {
+  ADMIN @deprecated
+  USER
+}
+
+"""Node with id"""
+interface Node {
+  id: ID!
+}
+
+union Result = User | Group
+
+type Root {
+  node(id: ID!): Node
+  search(text: String): [Result]
+  users(filter: Filter = {kinds: [USER], name: "a"}, first: Int = 10, kind: Kind = ADMIN): [User!]!
+}
+
+type User implements Node {
+  fullName: String
+  id: ID!
+  kind: Kind
+  name: String @deprecated(reason: "use fullName")
+}
+`
+
+var printerTestResolvers = map[string]ResolveFunction{
+    "Node.__resolveType": func(p ResolveParams) (interface{}, error) {
+        return nil, nil
+    },
+    "Result.__resolveType": func(p ResolveParams) (interface{}, error) {
+        return nil, nil
+    },
+}
+
+func TestPrintSchema(t *testing.T) {
+    schema, err := BuildSchema(printerTestSDL, printerTestResolvers)
+    if err != nil {
+        t.Fatalf("BuildSchema() error: %v", err)
+    }
+    printed := PrintSchema(schema)
+    if printed != printerTestPrinted {
+        t.Errorf("PrintSchema() =\n%s\nwant:\n%s", printed, printerTestPrinted)
+    }
+    // printed SDL must build the same schema
+    rebuilt, err := BuildSchema(printed, printerTestResolvers)
+    if err != nil {
+        t.Fatalf("BuildSchema(PrintSchema()) error: %v", err)
+    }
+    if reprinted := PrintSchema(rebuilt); reprinted != printed {
+        t.Errorf("PrintSchema(BuildSchema(PrintSchema())) =\n%s\nwant:\n%s", reprinted, printed)
+    }
+}
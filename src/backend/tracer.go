@@ -0,0 +1,108 @@
+// tracer.go
+
+package backend
+
+import (
+    "fast-graphql/src/frontend"
+    "fmt"
+    "log"
+    "strings"
+    "time"
+)
+
+// kinds of TraceEvent
+const (
+    TraceEventParse    = "parse"
+    TraceEventValidate = "validate"
+    TraceEventResolve  = "resolve"
+)
+
+/**
+ * TraceEvent
+ * structured event of request execution, passed into Tracer.
+ * ParentType, FieldName and Path are only set for resolve event, Path is the response path of field.
+ * Errors are in the same format as Result.Errors.
+ */
+type TraceEvent struct {
+    Kind          string
+    ParentType    string
+    FieldName     string
+    Path        []interface{}
+    Duration      time.Duration
+    Errors      []*ErrorInfo
+}
+
+// Tracer receives TraceEvent of every request, it should be safe for concurrent use.
+type Tracer interface {
+    Trace(event *TraceEvent)
+}
+
+// TracerFunc adapts an ordinary function to Tracer
+type TracerFunc func(event *TraceEvent)
+
+func (tracerFunc TracerFunc) Trace(event *TraceEvent) {
+    tracerFunc(event)
+}
+
+// package level Tracer, used when Request.Tracer is nil. nothing is traced when both are nil.
+var DefaultTracer Tracer
+
+// get Tracer of request, Request.Tracer first
+func getTracer(request Request) Tracer {
+    if request.Tracer != nil {
+        return request.Tracer
+    }
+    return DefaultTracer
+}
+
+// send event to tracer, skip if no tracer
+func trace(tracer Tracer, event *TraceEvent) {
+    if tracer == nil {
+        return
+    }
+    tracer.Trace(event)
+}
+
+// trace ResolveFunction of field
+func traceResolve(g *GlobalVariables, object *Object, field *frontend.Field, path []interface{}, startTime time.Time, err error) {
+    if g.Tracer == nil {
+        return
+    }
+    event := &TraceEvent{Kind: TraceEventResolve, ParentType: object.Name, FieldName: getFieldName(field), Path: path, Duration: time.Since(startTime)}
+    if err != nil {
+        event.Errors = []*ErrorInfo{newErrorInfo(err, newErrorLocation(field.Location), path)}
+    }
+    g.Tracer.Trace(event)
+}
+
+/**
+ * NewLogTracer
+ * Tracer which writes every event into logger as one line, e.g.
+ * resolve Query.user path=user duration=1.2ms
+ */
+func NewLogTracer(logger *log.Logger) Tracer {
+    return &logTracer{logger}
+}
+
+type logTracer struct {
+    logger *log.Logger
+}
+
+func (logTracer *logTracer) Trace(event *TraceEvent) {
+    var line strings.Builder
+    line.WriteString(event.Kind)
+    if event.Kind == TraceEventResolve {
+        line.WriteString(" " + event.ParentType + "." + event.FieldName + " path=")
+        for i, key := range event.Path {
+            if i > 0 {
+                line.WriteString(".")
+            }
+            line.WriteString(fmt.Sprintf("%v", key))
+        }
+    }
+    line.WriteString(" duration=" + event.Duration.String())
+    for _, errorInfo := range event.Errors {
+        line.WriteString(fmt.Sprintf(" error=%q", errorInfo.Message))
+    }
+    logTracer.logger.Println(line.String())
+}
@@ -0,0 +1,135 @@
+// tracer_test.go
+
+package backend
+
+import (
+    "bytes"
+    "errors"
+    "fmt"
+    "log"
+    "reflect"
+    "regexp"
+    "testing"
+)
+
+func newTracerTestSchema(t *testing.T) Schema {
+    t.Helper()
+    user, _ := NewObject(ObjectTemplate{
+        Name: "User",
+        Fields: ObjectFields{
+            "name": &ObjectField{Name: "name", Type: String},
+            "fail": &ObjectField{Name: "fail", Type: String, ResolveFunction: func(p ResolveParams) (interface{}, error) {
+                return nil, errors.New("failed")
+            }},
+        },
+    })
+    query, _ := NewObject(ObjectTemplate{
+        Name: "Query",
+        Fields: ObjectFields{
+            "users": &ObjectField{Name: "users", Type: NewList(user), ResolveFunction: func(p ResolveParams) (interface{}, error) {
+                return []interface{}{map[string]interface{}{"name": "a"}, map[string]interface{}{"name": "b"}}, nil
+            }},
+        },
+    })
+    return newExecuteTestSchema(t, SchemaTemplate{Query: query})
+}
+
+// event description without duration, e.g. "resolve Query.users [users]" or "parse error: ..."
+func describeTraceEvent(event *TraceEvent) string {
+    description := event.Kind
+    if event.Kind == TraceEventResolve {
+        description += fmt.Sprintf(" %s.%s %v", event.ParentType, event.FieldName, event.Path)
+    }
+    for _, errorInfo := range event.Errors {
+        description += " error: " + errorInfo.Message
+    }
+    return description
+}
+
+/**
+ * Tracer tests
+ * events are described by describeTraceEvent in the order they are traced.
+ */
+var tracerTests = []struct {
+    name   string
+    query  string
+    events []string
+}{
+    {
+        name:  "events",
+        query: `{ users { name } }`,
+        events: []string{
+            "parse", "validate",
+            "resolve Query.users [users]", "resolve User.name [users 0 name]", "resolve User.name [users 1 name]",
+        },
+    },
+    {
+        name:  "resolver error",
+        query: `{ users { fail } }`,
+        events: []string{
+            "parse", "validate",
+            "resolve Query.users [users]", "resolve User.fail [users 0 fail] error: failed", "resolve User.fail [users 1 fail] error: failed",
+        },
+    },
+    {
+        name:  "syntax error",
+        query: `{ users `,
+        events: []string{"parse error: Syntax Error: unexpected 'EOF', expected Name."},
+    },
+    {
+        name:  "validation error",
+        query: `{ user }`,
+        events: []string{"parse", "validate error: Cannot query field \"user\" on type \"Query\"."},
+    },
+}
+
+func TestTracer(t *testing.T) {
+    schema := newTracerTestSchema(t)
+    for _, test := range tracerTests {
+        var events []string
+        tracer := TracerFunc(func(event *TraceEvent) {
+            events = append(events, describeTraceEvent(event))
+        })
+        Execute(Request{Schema: schema, Query: test.query, Tracer: tracer})
+        if !reflect.DeepEqual(events, test.events) {
+            t.Errorf("%s: events =\n    %q\nwant\n    %q", test.name, events, test.events)
+        }
+    }
+}
+
+func TestDefaultTracer(t *testing.T) {
+    schema := newTracerTestSchema(t)
+    var defaultEvents, requestEvents int
+    DefaultTracer = TracerFunc(func(event *TraceEvent) {
+        defaultEvents++
+    })
+    defer func() {
+        DefaultTracer = nil
+    }()
+    Execute(Request{Schema: schema, Query: `{ users { name } }`})
+    if defaultEvents == 0 {
+        t.Errorf("DefaultTracer got no event")
+    }
+    defaultEvents = 0
+    Execute(Request{Schema: schema, Query: `{ users { name } }`, Tracer: TracerFunc(func(event *TraceEvent) {
+        requestEvents++
+    })})
+    if defaultEvents != 0 || requestEvents == 0 {
+        t.Errorf("Request.Tracer got %d events and DefaultTracer got %d events, want Request.Tracer only", requestEvents, defaultEvents)
+    }
+}
+
+func TestLogTracer(t *testing.T) {
+    schema := newTracerTestSchema(t)
+    var buffer bytes.Buffer
+    Execute(Request{Schema: schema, Query: `{ users { fail } }`, Tracer: NewLogTracer(log.New(&buffer, "", 0))})
+    logged := regexp.MustCompile(`duration=\S+`).ReplaceAllString(buffer.String(), "duration=D")
+    want := "parse duration=D\n" +
+        "validate duration=D\n" +
+        "resolve Query.users path=users duration=D\n" +
+        "resolve User.fail path=users.0.fail duration=D error=\"failed\"\n" +
+        "resolve User.fail path=users.1.fail duration=D error=\"failed\"\n"
+    if logged != want {
+        t.Errorf("logged =\n%s\nwant:\n%s", logged, want)
+    }
+}
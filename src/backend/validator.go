@@ -0,0 +1,1471 @@
+// validator.go
+package backend
+
+import (
+    "fast-graphql/src/frontend"
+    "fmt"
+    "reflect"
+    "strings"
+    "sort"
+)
+
+/**
+ * Validation
+ * A Document must be valid against the Schema before execution, the rules below are
+ * ported from GraphQL specification section 5 "Validation". Validate() runs every rule
+ * and collects all violations as located ErrorInfo, so a typo in query will not reach
+ * any ResolveFunction.
+ * Every rule runs even when noFragmentCyclesRule has reported a cycle, so a rule which
+ * expands fragment spreads must skip fragments already visited on it's path.
+ *
+ */
+
+type validationContext struct {
+    schema        Schema
+    document     *frontend.Document
+    typeMap       map[string]Type
+    operations []*frontend.OperationDefinition
+    fragmentList []*frontend.FragmentDefinition
+    fragments     map[string]*frontend.FragmentDefinition
+    errors     []*ErrorInfo
+}
+
+type validationRule func(context *validationContext)
+
+var specifiedRules = []validationRule{
+    executableDefinitionsRule,
+    uniqueOperationNamesRule,
+    loneAnonymousOperationRule,
+    knownTypeNamesRule,
+    fragmentsOnCompositeTypesRule,
+    possibleFragmentSpreadsRule,
+    variablesAreInputTypesRule,
+    fieldsOnCorrectTypeRule,
+    scalarLeafsRule,
+    knownArgumentNamesRule,
+    providedRequiredArgumentsRule,
+    valuesOfCorrectTypeRule,
+    uniqueArgumentNamesRule,
+    knownDirectivesRule,
+    uniqueDirectivesPerLocationRule,
+    uniqueFragmentNamesRule,
+    knownFragmentNamesRule,
+    noUnusedFragmentsRule,
+    noFragmentCyclesRule,
+    uniqueVariableNamesRule,
+    noUndefinedVariablesRule,
+    noUnusedVariablesRule,
+    variablesInAllowedPositionRule,
+    overlappingFieldsCanBeMergedRule,
+    uniqueInputFieldNamesRule,
+}
+
+// validate document by all specified rules, return nil if document is valid
+func Validate(schema Schema, document *frontend.Document) []*ErrorInfo {
+    context := newValidationContext(schema, document)
+    for _, rule := range specifiedRules {
+        rule(context)
+    }
+    return context.errors
+}
+
+func newValidationContext(schema Schema, document *frontend.Document) *validationContext {
+    context := &validationContext{
+        schema    : schema,
+        document  : document,
+        typeMap   : schema.GetTypeMap(),
+        fragments : make(map[string]*frontend.FragmentDefinition),
+    }
+    for _, definition := range document.GetDefinitions() {
+        switch d := definition.(type) {
+        case *frontend.OperationDefinition:
+            context.operations = append(context.operations, d)
+        case *frontend.FragmentDefinition:
+            context.fragmentList = append(context.fragmentList, d)
+            // duplicated fragment name will reported by uniqueFragmentNamesRule
+            if _, ok := context.fragments[d.Name.Value]; !ok {
+                context.fragments[d.Name.Value] = d
+            }
+        }
+    }
+    return context
+}
+
+func (context *validationContext) reportError(location frontend.Location, format string, a ...interface{}) {
+    errorInfo := ErrorInfo{Message: fmt.Sprintf(format, a...), Locations: []*ErrorLocation{newErrorLocation(location)}}
+    context.errors = append(context.errors, &errorInfo)
+}
+
+// get root Object of operation, nil if schema does not support this OperationType
+func (context *validationContext) getOperationRootType(operationDefinition *frontend.OperationDefinition) Type {
+    var rootObject *Object
+    switch operationDefinition.OperationType {
+    case frontend.OperationTypeQuery:
+        rootObject = context.schema.Query
+    case frontend.OperationTypeMutation:
+        rootObject = context.schema.Mutation
+    case frontend.OperationTypeSubscription:
+        rootObject = context.schema.Subscription
+    }
+    if rootObject == nil {
+        return nil
+    }
+    return rootObject
+}
+
+func (context *validationContext) getTypeByName(name *frontend.Name) Type {
+    if name == nil {
+        return nil
+    }
+    if targetType, ok := context.typeMap[name.Value]; ok {
+        return targetType
+    }
+    return nil
+}
+
+/**
+ * walkSelectionSet visit every Selection under SelectionSet with it's parent type,
+ * parentType is nil when it can not be determined (unknown field or type condition).
+ * FragmentSpread does not step into target FragmentDefinition, walkDocument visit
+ * FragmentDefinition separately.
+ */
+func (context *validationContext) walkSelectionSet(parentType Type, selectionSet *frontend.SelectionSet, visit func(parentType Type, selection frontend.Selection)) {
+    if selectionSet == nil {
+        return
+    }
+    for _, selection := range selectionSet.GetSelections() {
+        visit(parentType, selection)
+        switch s := selection.(type) {
+        case *frontend.Field:
+            var subType Type
+            if objectField := getFieldDefinition(&context.schema, parentType, s.Name.Value); objectField != nil {
+                subType = getNamedType(objectField.Type)
+            }
+            context.walkSelectionSet(subType, s.SelectionSet, visit)
+        case *frontend.InlineFragment:
+            fragmentType := parentType
+            if s.TypeCondition != nil {
+                fragmentType = context.getTypeByName(s.TypeCondition)
+            }
+            context.walkSelectionSet(fragmentType, s.SelectionSet, visit)
+        }
+    }
+}
+
+func (context *validationContext) walkDocument(visit func(parentType Type, selection frontend.Selection)) {
+    for _, operationDefinition := range context.operations {
+        context.walkSelectionSet(context.getOperationRootType(operationDefinition), operationDefinition.SelectionSet, visit)
+    }
+    for _, fragmentDefinition := range context.fragmentList {
+        context.walkSelectionSet(context.getTypeByName(fragmentDefinition.TypeCondition), fragmentDefinition.SelectionSet, visit)
+    }
+}
+
+// get Directive definition by name, nil if not found
+func (context *validationContext) getDirective(name string) *Directive {
+    return context.schema.GetDirective(name)
+}
+
+// visit directives of operations, fragments and selections with their DirectiveLocation
+func (context *validationContext) walkDirectives(visit func(directives []*frontend.Directive, location string)) {
+    operationLocations := map[int]string{
+        frontend.OperationTypeQuery        : DirectiveLocationQuery,
+        frontend.OperationTypeMutation     : DirectiveLocationMutation,
+        frontend.OperationTypeSubscription : DirectiveLocationSubscription,
+    }
+    for _, operationDefinition := range context.operations {
+        visit(operationDefinition.Directives, operationLocations[operationDefinition.OperationType])
+    }
+    for _, fragmentDefinition := range context.fragmentList {
+        visit(fragmentDefinition.Directives, DirectiveLocationFragmentDefinition)
+    }
+    context.walkDocument(func(parentType Type, selection frontend.Selection) {
+        switch s := selection.(type) {
+        case *frontend.Field:
+            visit(s.Directives, DirectiveLocationField)
+        case *frontend.FragmentSpread:
+            visit(s.Directives, DirectiveLocationFragmentSpread)
+        case *frontend.InlineFragment:
+            visit(s.Directives, DirectiveLocationInlineFragment)
+        }
+    })
+}
+
+// visit arguments of fields and directives with their schema defined Arguments, argumentDefinitions is nil for unknown field or directive
+func (context *validationContext) walkArguments(visit func(arguments []*frontend.Argument, argumentDefinitions *Arguments)) {
+    context.walkDocument(func(parentType Type, selection frontend.Selection) {
+        field, ok := selection.(*frontend.Field)
+        if !ok {
+            return
+        }
+        if objectField := getFieldDefinition(&context.schema, parentType, field.Name.Value); objectField != nil {
+            visit(field.Arguments, objectField.Arguments)
+        } else {
+            visit(field.Arguments, nil)
+        }
+    })
+    context.walkDirectives(func(directives []*frontend.Directive, location string) {
+        for _, directive := range directives {
+            if directiveDefinition := context.getDirective(directive.Name.Value); directiveDefinition != nil {
+                visit(directive.Arguments, directiveDefinition.Arguments)
+            } else {
+                visit(directive.Arguments, nil)
+            }
+        }
+    })
+}
+
+// get fragment spreads under selectionSet, include spreads in nested fields and inline fragments
+func getFragmentSpreads(selectionSet *frontend.SelectionSet) []*frontend.FragmentSpread {
+    var fragmentSpreads []*frontend.FragmentSpread
+    if selectionSet == nil {
+        return nil
+    }
+    for _, selection := range selectionSet.GetSelections() {
+        if fragmentSpread, ok := selection.(*frontend.FragmentSpread); ok {
+            fragmentSpreads = append(fragmentSpreads, fragmentSpread)
+            continue
+        }
+        fragmentSpreads = append(fragmentSpreads, getFragmentSpreads(selection.GetSelectionSet())...)
+    }
+    return fragmentSpreads
+}
+
+// get FragmentDefinitions referenced by selectionSet, include fragments referenced by fragments
+func (context *validationContext) getRecursivelyReferencedFragments(selectionSet *frontend.SelectionSet) []*frontend.FragmentDefinition {
+    var referenced []*frontend.FragmentDefinition
+    visited := make(map[string]bool)
+    pending := []*frontend.SelectionSet{selectionSet}
+    for len(pending) > 0 {
+        target := pending[len(pending)-1]
+        pending = pending[:len(pending)-1]
+        for _, fragmentSpread := range getFragmentSpreads(target) {
+            fragmentName := fragmentSpread.Name.Value
+            if visited[fragmentName] {
+                continue
+            }
+            visited[fragmentName] = true
+            if fragmentDefinition, ok := context.fragments[fragmentName]; ok {
+                referenced = append(referenced, fragmentDefinition)
+                pending = append(pending, fragmentDefinition.SelectionSet)
+            }
+        }
+    }
+    return referenced
+}
+
+type variableUsage struct {
+    variable    frontend.Variable
+    location    frontend.Location
+    // input type expected at the position, and whether the position has a default value
+    inputType   Type
+    hasDefault  bool
+}
+
+// get all variables referenced in arguments of fields and directives under selectionSet
+func getVariableUsages(selectionSet *frontend.SelectionSet) []variableUsage {
+    var usages []variableUsage
+    if selectionSet == nil {
+        return nil
+    }
+    collectFromDirectives := func(directives []*frontend.Directive) {
+        for _, directive := range directives {
+            usages = append(usages, getArgumentsVariableUsages(directive.Arguments)...)
+        }
+    }
+    for _, selection := range selectionSet.GetSelections() {
+        switch s := selection.(type) {
+        case *frontend.Field:
+            usages = append(usages, getArgumentsVariableUsages(s.Arguments)...)
+            collectFromDirectives(s.Directives)
+        case *frontend.FragmentSpread:
+            collectFromDirectives(s.Directives)
+        case *frontend.InlineFragment:
+            collectFromDirectives(s.Directives)
+        }
+        usages = append(usages, getVariableUsages(selection.GetSelectionSet())...)
+    }
+    return usages
+}
+
+func getArgumentsVariableUsages(arguments []*frontend.Argument) []variableUsage {
+    var usages []variableUsage
+    for _, argument := range arguments {
+        usages = append(usages, getValueVariableUsages(argument.Value)...)
+    }
+    return usages
+}
+
+func getValueVariableUsages(value frontend.Value) []variableUsage {
+    var usages []variableUsage
+    switch v := value.(type) {
+    case frontend.Variable:
+        usages = append(usages, variableUsage{variable: v, location: v.Location})
+    case frontend.ListValue:
+        for _, item := range v.Value {
+            usages = append(usages, getValueVariableUsages(item)...)
+        }
+    case frontend.ObjectValue:
+        for _, objectField := range v.Value {
+            usages = append(usages, getValueVariableUsages(objectField.Value)...)
+        }
+    }
+    return usages
+}
+
+// get variables used in value with the input type expected at their positions, value not matching inputType is skipped
+func getValueTypedVariableUsages(value frontend.Value, inputType Type, hasDefault bool) []variableUsage {
+    var usages []variableUsage
+    switch v := value.(type) {
+    case frontend.Variable:
+        usages = append(usages, variableUsage{v, v.Location, inputType, hasDefault})
+    case frontend.ListValue:
+        list, ok := getNullableType(inputType).(*List)
+        if !ok {
+            return nil
+        }
+        for _, item := range v.Value {
+            usages = append(usages, getValueTypedVariableUsages(item, list.Payload, false)...)
+        }
+    case frontend.ObjectValue:
+        inputObject, ok := getNullableType(inputType).(*InputObject)
+        if !ok {
+            return nil
+        }
+        for _, objectField := range v.Value {
+            if inputObjectField, ok := inputObject.Fields[objectField.Name.Value]; ok {
+                usages = append(usages, getValueTypedVariableUsages(objectField.Value, inputObjectField.Type, inputObjectField.DefaultValue != nil)...)
+            }
+        }
+    }
+    return usages
+}
+
+// get location of Value in document
+func getValueLocation(value frontend.Value) frontend.Location {
+    switch v := value.(type) {
+    case frontend.Variable:
+        return v.Location
+    case frontend.IntValue:
+        return v.Location
+    case frontend.FloatValue:
+        return v.Location
+    case frontend.StringValue:
+        return v.Location
+    case frontend.BooleanValue:
+        return v.Location
+    case frontend.NullValue:
+        return v.Location
+    case frontend.EnumValue:
+        return v.Location
+    case frontend.ListValue:
+        return v.Location
+    case frontend.ObjectValue:
+        return v.Location
+    }
+    return frontend.Location{}
+}
+
+// get type name of definition for error message, e.g. "ObjectTypeDefinition"
+func getDefinitionLocation(definition frontend.Definition) (string, frontend.Location) {
+    definitionValue := reflect.Indirect(reflect.ValueOf(definition))
+    var location frontend.Location
+    if locationField := definitionValue.FieldByName("Location"); locationField.IsValid() {
+        location = locationField.Interface().(frontend.Location)
+    }
+    return definitionValue.Type().Name(), location
+}
+
+// unwrap List and NonNull to get the named type
+func getNamedType(targetType Type) Type {
+    for {
+        switch t := targetType.(type) {
+        case *List:
+            targetType = t.Payload
+            continue
+        case *NonNull:
+            targetType = t.OfType
+            continue
+        }
+        return targetType
+    }
+}
+
+// get ObjectField by name from parentType (Object or Interface), nil if not found
+func getObjectField(parentType Type, fieldName string) *ObjectField {
+    var fields ObjectFields
+    switch t := parentType.(type) {
+    case *Object:
+        if t != nil {
+            fields = t.Fields
+        }
+    case *Interface:
+        if t != nil {
+            fields = t.Fields
+        }
+    }
+    if objectField, ok := fields[fieldName]; ok {
+        return objectField
+    }
+    return nil
+}
+
+func isLeafType(targetType Type) bool {
+    switch targetType.(type) {
+    case *Scalar, *Enum:
+        return true
+    }
+    return false
+}
+
+func isCompositeType(targetType Type) bool {
+    switch targetType.(type) {
+    case *Object, *Interface, *Union:
+        return true
+    }
+    return false
+}
+
+// get Object types which parentType could be at runtime
+func (context *validationContext) getPossibleTypes(targetType Type) []*Object {
+    if object, ok := targetType.(*Object); ok {
+        return []*Object{object}
+    }
+    return context.schema.GetPossibleTypes(targetType)
+}
+
+// check two composite types have at least one possible Object type in common
+func (context *validationContext) doTypesOverlap(typeA Type, typeB Type) bool {
+    if typeA == typeB {
+        return true
+    }
+    for _, objectA := range context.getPossibleTypes(typeA) {
+        for _, objectB := range context.getPossibleTypes(typeB) {
+            if objectA.Name == objectB.Name {
+                return true
+            }
+        }
+    }
+    return false
+}
+
+func isInputType(targetType Type) bool {
+    namedType := getNamedType(targetType)
+    if _, ok := namedType.(*InputObject); ok {
+        return true
+    }
+    return isLeafType(namedType)
+}
+
+// get named type name of frontend type, e.g. "[Int!]!" returns "Int"
+func getFrontendNamedTypeName(variableType frontend.Type) string {
+    switch t := variableType.(type) {
+    case *frontend.NamedType:
+        return t.Value
+    case frontend.ListType:
+        if len(t.Type) > 0 {
+            return getFrontendNamedTypeName(t.Type[0])
+        }
+    case frontend.NonNullType:
+        return getFrontendNamedTypeName(t.Type)
+    }
+    return ""
+}
+
+// check maybeSubType can be used where superType is expected, e.g. "Int!" for "Int", "[Int!]" for "[Int]"
+func isTypeSubTypeOf(maybeSubType Type, superType Type) bool {
+    if superNonNull, ok := superType.(*NonNull); ok {
+        subNonNull, ok := maybeSubType.(*NonNull)
+        return ok && isTypeSubTypeOf(subNonNull.OfType, superNonNull.OfType)
+    }
+    if subNonNull, ok := maybeSubType.(*NonNull); ok {
+        return isTypeSubTypeOf(subNonNull.OfType, superType)
+    }
+    if superList, ok := superType.(*List); ok {
+        subList, ok := maybeSubType.(*List)
+        return ok && isTypeSubTypeOf(subList.Payload, superList.Payload)
+    }
+    if _, ok := maybeSubType.(*List); ok {
+        return false
+    }
+    return maybeSubType.GetName() == superType.GetName()
+}
+
+// check two field types produce different response shapes, composite types are compared by their sub-fields
+func doTypesConflict(typeA Type, typeB Type) bool {
+    listA, aIsList := typeA.(*List)
+    listB, bIsList := typeB.(*List)
+    if aIsList || bIsList {
+        return !(aIsList && bIsList) || doTypesConflict(listA.Payload, listB.Payload)
+    }
+    nonNullA, aIsNonNull := typeA.(*NonNull)
+    nonNullB, bIsNonNull := typeB.(*NonNull)
+    if aIsNonNull || bIsNonNull {
+        return !(aIsNonNull && bIsNonNull) || doTypesConflict(nonNullA.OfType, nonNullB.OfType)
+    }
+    if isLeafType(typeA) || isLeafType(typeB) {
+        return typeA.GetName() != typeB.GetName()
+    }
+    return false
+}
+
+
+/**
+ * Executable Definitions
+ * GraphQL execution will only consider OperationDefinition and FragmentDefinition.
+ */
+func executableDefinitionsRule(context *validationContext) {
+    for _, definition := range context.document.GetDefinitions() {
+        switch definition.(type) {
+        case *frontend.OperationDefinition, *frontend.FragmentDefinition:
+            continue
+        }
+        definitionName, location := getDefinitionLocation(definition)
+        context.reportError(location, "The %s definition is not executable.", definitionName)
+    }
+}
+
+/**
+ * Operation Name Uniqueness
+ * Each named operation must be unique within a document when referred to by its name.
+ */
+func uniqueOperationNamesRule(context *validationContext) {
+    known := make(map[string]bool)
+    for _, operationDefinition := range context.operations {
+        if operationDefinition.Name == nil {
+            continue
+        }
+        operationName := operationDefinition.Name.Value
+        if known[operationName] {
+            context.reportError(operationDefinition.Location, "There can be only one operation named \"%s\".", operationName)
+        }
+        known[operationName] = true
+    }
+}
+
+/**
+ * Lone Anonymous Operation
+ * Anonymous operation is only allowed when it is the only operation in document.
+ */
+func loneAnonymousOperationRule(context *validationContext) {
+    if len(context.operations) < 2 {
+        return
+    }
+    for _, operationDefinition := range context.operations {
+        if operationDefinition.Name == nil {
+            context.reportError(operationDefinition.Location, "This anonymous operation must be the only defined operation.")
+        }
+    }
+}
+
+/**
+ * Known Type Names
+ * Types referenced by variable definitions and type conditions must be defined in schema.
+ */
+func knownTypeNamesRule(context *validationContext) {
+    checkName := func(typeName string, location frontend.Location) {
+        if _, ok := context.typeMap[typeName]; !ok {
+            context.reportError(location, "Unknown type \"%s\".", typeName)
+        }
+    }
+    for _, operationDefinition := range context.operations {
+        for _, variableDefinition := range operationDefinition.VariableDefinitions {
+            checkName(getFrontendNamedTypeName(variableDefinition.Type), variableDefinition.Location)
+        }
+    }
+    for _, fragmentDefinition := range context.fragmentList {
+        checkName(fragmentDefinition.TypeCondition.Value, fragmentDefinition.TypeCondition.Location)
+    }
+    context.walkDocument(func(parentType Type, selection frontend.Selection) {
+        if inlineFragment, ok := selection.(*frontend.InlineFragment); ok && inlineFragment.TypeCondition != nil {
+            checkName(inlineFragment.TypeCondition.Value, inlineFragment.TypeCondition.Location)
+        }
+    })
+}
+
+/**
+ * Fragments On Composite Types
+ * Fragments can only be declared on unions, interfaces, and objects.
+ */
+func fragmentsOnCompositeTypesRule(context *validationContext) {
+    for _, fragmentDefinition := range context.fragmentList {
+        fragmentType := context.getTypeByName(fragmentDefinition.TypeCondition)
+        if fragmentType != nil && !isCompositeType(fragmentType) {
+            context.reportError(fragmentDefinition.Name.Location, "Fragment \"%s\" cannot condition on non composite type \"%s\".", fragmentDefinition.Name.Value, fragmentDefinition.TypeCondition.Value)
+        }
+    }
+    context.walkDocument(func(parentType Type, selection frontend.Selection) {
+        inlineFragment, ok := selection.(*frontend.InlineFragment)
+        if !ok || inlineFragment.TypeCondition == nil {
+            return
+        }
+        fragmentType := context.getTypeByName(inlineFragment.TypeCondition)
+        if fragmentType != nil && !isCompositeType(fragmentType) {
+            context.reportError(inlineFragment.TypeCondition.Location, "Fragment cannot condition on non composite type \"%s\".", inlineFragment.TypeCondition.Value)
+        }
+    })
+}
+
+/**
+ * Fragment Spread Is Possible
+ * A fragment spread is only valid if its type condition could ever possibly apply within the parent type.
+ */
+func possibleFragmentSpreadsRule(context *validationContext) {
+    context.walkDocument(func(parentType Type, selection frontend.Selection) {
+        if parentType == nil || !isCompositeType(parentType) {
+            return
+        }
+        switch s := selection.(type) {
+        case *frontend.InlineFragment:
+            if s.TypeCondition == nil {
+                return
+            }
+            fragmentType := context.getTypeByName(s.TypeCondition)
+            if fragmentType == nil || !isCompositeType(fragmentType) || context.doTypesOverlap(parentType, fragmentType) {
+                return
+            }
+            context.reportError(s.Location, "Fragment cannot be spread here as objects of type \"%s\" can never be of type \"%s\".", parentType.GetName(), fragmentType.GetName())
+        case *frontend.FragmentSpread:
+            fragmentDefinition, ok := context.fragments[s.Name.Value]
+            if !ok {
+                return
+            }
+            fragmentType := context.getTypeByName(fragmentDefinition.TypeCondition)
+            if fragmentType == nil || !isCompositeType(fragmentType) || context.doTypesOverlap(parentType, fragmentType) {
+                return
+            }
+            context.reportError(s.Location, "Fragment \"%s\" cannot be spread here as objects of type \"%s\" can never be of type \"%s\".", s.Name.Value, parentType.GetName(), fragmentType.GetName())
+        }
+    })
+}
+
+/**
+ * Variables Are Input Types
+ * Variables can only be input types, e.g. scalars, enums, or input objects.
+ */
+func variablesAreInputTypesRule(context *validationContext) {
+    for _, operationDefinition := range context.operations {
+        for _, variableDefinition := range operationDefinition.VariableDefinitions {
+            typeName := getFrontendNamedTypeName(variableDefinition.Type)
+            if variableType, ok := context.typeMap[typeName]; ok && !isInputType(variableType) {
+                context.reportError(variableDefinition.Location, "Variable \"$%s\" cannot be non-input type \"%s\".", variableDefinition.Variable.Value, typeName)
+            }
+        }
+    }
+}
+
+/**
+ * Field Selections on Objects, Interfaces, and Unions Types
+ * The target field of a field selection must be defined on the scoped type of the selection set.
+ */
+func fieldsOnCorrectTypeRule(context *validationContext) {
+    context.walkDocument(func(parentType Type, selection frontend.Selection) {
+        // selections under leaf type are reported by scalarLeafsRule and fragmentsOnCompositeTypesRule
+        field, ok := selection.(*frontend.Field)
+        if !ok || parentType == nil || !isCompositeType(parentType) {
+            return
+        }
+        if getFieldDefinition(&context.schema, parentType, field.Name.Value) == nil {
+            context.reportError(field.Location, "Cannot query field \"%s\" on type \"%s\".", field.Name.Value, parentType.GetName())
+        }
+    })
+}
+
+/**
+ * Leaf Field Selections
+ * Field selections on scalars are never allowed, and selections on objects are required.
+ */
+func scalarLeafsRule(context *validationContext) {
+    context.walkDocument(func(parentType Type, selection frontend.Selection) {
+        field, ok := selection.(*frontend.Field)
+        if !ok {
+            return
+        }
+        objectField := getFieldDefinition(&context.schema, parentType, field.Name.Value)
+        if objectField == nil {
+            return
+        }
+        fieldType := getNamedType(objectField.Type)
+        if isLeafType(fieldType) && field.SelectionSet != nil {
+            context.reportError(field.Location, "Field \"%s\" must not have a selection since type \"%s\" has no subfields.", field.Name.Value, fieldType.GetName())
+        }
+        if !isLeafType(fieldType) && field.SelectionSet == nil {
+            context.reportError(field.Location, "Field \"%s\" of type \"%s\" must have a selection of subfields.", field.Name.Value, fieldType.GetName())
+        }
+    })
+}
+
+/**
+ * Argument Names
+ * Every argument provided to a field or directive must be defined in the ObjectField.Arguments or Directive.Arguments.
+ */
+func knownArgumentNamesRule(context *validationContext) {
+    context.walkDirectives(func(directives []*frontend.Directive, location string) {
+        for _, directive := range directives {
+            directiveDefinition := context.getDirective(directive.Name.Value)
+            if directiveDefinition == nil {
+                continue
+            }
+            for _, argument := range directive.Arguments {
+                if directiveDefinition.Arguments != nil {
+                    if _, ok := (*directiveDefinition.Arguments)[argument.Name.Value]; ok {
+                        continue
+                    }
+                }
+                context.reportError(argument.Location, "Unknown argument \"%s\" on directive \"@%s\".", argument.Name.Value, directive.Name.Value)
+            }
+        }
+    })
+    context.walkDocument(func(parentType Type, selection frontend.Selection) {
+        field, ok := selection.(*frontend.Field)
+        if !ok {
+            return
+        }
+        objectField := getFieldDefinition(&context.schema, parentType, field.Name.Value)
+        if objectField == nil {
+            return
+        }
+        for _, argument := range field.Arguments {
+            if objectField.Arguments != nil {
+                if _, ok := (*objectField.Arguments)[argument.Name.Value]; ok {
+                    continue
+                }
+            }
+            context.reportError(argument.Location, "Unknown argument \"%s\" on field \"%s\" of type \"%s\".", argument.Name.Value, field.Name.Value, parentType.GetName())
+        }
+    })
+}
+
+/**
+ * Required Arguments
+ * Arguments is required if the argument type is NonNull and does not have a default value, required arguments must be provided.
+ */
+func providedRequiredArgumentsRule(context *validationContext) {
+    // sorted argument names for stable error order
+    getRequiredArgumentNames := func(arguments *Arguments) []string {
+        var argumentNames []string
+        if arguments == nil {
+            return nil
+        }
+        for argumentName, argument := range *arguments {
+            if _, ok := argument.Type.(*NonNull); ok && argument.DefaultValue == nil {
+                argumentNames = append(argumentNames, argumentName)
+            }
+        }
+        sort.Strings(argumentNames)
+        return argumentNames
+    }
+    // explicit null is provided, it is reported by valuesOfCorrectTypeRule
+    isArgumentProvided := func(arguments []*frontend.Argument, argumentName string) bool {
+        for _, argument := range arguments {
+            if argument.Name.Value == argumentName {
+                return true
+            }
+        }
+        return false
+    }
+    context.walkDirectives(func(directives []*frontend.Directive, location string) {
+        for _, directive := range directives {
+            directiveDefinition := context.getDirective(directive.Name.Value)
+            if directiveDefinition == nil {
+                continue
+            }
+            for _, argumentName := range getRequiredArgumentNames(directiveDefinition.Arguments) {
+                if !isArgumentProvided(directive.Arguments, argumentName) {
+                    argumentType := (*directiveDefinition.Arguments)[argumentName].Type
+                    context.reportError(directive.Location, "Directive \"@%s\" argument \"%s\" of type \"%s\" is required, but it was not provided.", directive.Name.Value, argumentName, argumentType.GetName())
+                }
+            }
+        }
+    })
+    context.walkDocument(func(parentType Type, selection frontend.Selection) {
+        field, ok := selection.(*frontend.Field)
+        if !ok {
+            return
+        }
+        objectField := getFieldDefinition(&context.schema, parentType, field.Name.Value)
+        if objectField == nil {
+            return
+        }
+        for _, argumentName := range getRequiredArgumentNames(objectField.Arguments) {
+            if !isArgumentProvided(field.Arguments, argumentName) {
+                argumentType := (*objectField.Arguments)[argumentName].Type
+                context.reportError(field.Location, "Field \"%s\" argument \"%s\" of type \"%s\" is required, but it was not provided.", field.Name.Value, argumentName, argumentType.GetName())
+            }
+        }
+    })
+}
+
+/**
+ * Values of Correct Type
+ * Literal values of arguments and variable default values must be coercible to the type expected at their position.
+ */
+func valuesOfCorrectTypeRule(context *validationContext) {
+    context.walkArguments(func(arguments []*frontend.Argument, argumentDefinitions *Arguments) {
+        if argumentDefinitions == nil {
+            return
+        }
+        for _, argument := range arguments {
+            argumentDefinition, ok := (*argumentDefinitions)[argument.Name.Value]
+            if !ok {
+                continue
+            }
+            // explicit null for NonNull argument is invalid even if the argument has a default value
+            context.checkValueType(argument.Value, argumentDefinition.Type)
+        }
+    })
+    for _, operationDefinition := range context.operations {
+        for _, variableDefinition := range operationDefinition.VariableDefinitions {
+            if variableDefinition.DefaultValue == nil {
+                continue
+            }
+            // unknown or non-input variable type is reported by knownTypeNamesRule and variablesAreInputTypesRule
+            variableType, err := getVariableType(&context.schema, variableDefinition.Type)
+            if err != nil {
+                continue
+            }
+            context.checkValueType(variableDefinition.DefaultValue, variableType)
+        }
+    }
+}
+
+// report literal value which can not be coerced to input type, variables are checked by variablesInAllowedPositionRule
+func (context *validationContext) checkValueType(value frontend.Value, targetType Type) {
+    if _, ok := value.(frontend.Variable); ok {
+        return
+    }
+    location := getValueLocation(value)
+    if nonNull, ok := targetType.(*NonNull); ok {
+        if _, isNull := value.(frontend.NullValue); isNull {
+            context.reportError(location, "Expected value of type \"%s\", found null.", targetType.GetName())
+            return
+        }
+        context.checkValueType(value, nonNull.OfType)
+        return
+    }
+    if _, ok := value.(frontend.NullValue); ok {
+        return
+    }
+    switch t := targetType.(type) {
+    case *List:
+        // input coercion of list accepts single item
+        listValue, ok := value.(frontend.ListValue)
+        if !ok {
+            context.checkValueType(value, t.Payload)
+            return
+        }
+        for _, item := range listValue.Value {
+            context.checkValueType(item, t.Payload)
+        }
+    case *InputObject:
+        objectValue, ok := value.(frontend.ObjectValue)
+        if !ok {
+            context.reportError(location, "Expected value of type \"%s\", found %s.", t.Name, getValueLiteral(value))
+            return
+        }
+        provided := make(map[string]bool, len(objectValue.Value))
+        for _, objectField := range objectValue.Value {
+            fieldName := objectField.Name.Value
+            provided[fieldName] = true
+            inputObjectField, ok := t.Fields[fieldName]
+            if !ok {
+                context.reportError(objectField.Location, "Field \"%s\" is not defined by type \"%s\".", fieldName, t.Name)
+                continue
+            }
+            context.checkValueType(objectField.Value, inputObjectField.Type)
+        }
+        // sorted field names for stable error order
+        var fieldNames []string
+        for fieldName := range t.Fields {
+            fieldNames = append(fieldNames, fieldName)
+        }
+        sort.Strings(fieldNames)
+        for _, fieldName := range fieldNames {
+            inputObjectField := t.Fields[fieldName]
+            if _, ok := inputObjectField.Type.(*NonNull); ok && !provided[fieldName] && inputObjectField.DefaultValue == nil {
+                context.reportError(location, "Field \"%s.%s\" of required type \"%s\" was not provided.", t.Name, fieldName, inputObjectField.Type.GetName())
+            }
+        }
+    case *Enum:
+        enumValue, ok := value.(frontend.EnumValue)
+        if !ok {
+            context.reportError(location, "Enum \"%s\" cannot represent non-enum value: %s.", t.Name, getValueLiteral(value))
+            return
+        }
+        if _, ok := t.Values[enumValue.Value.Value]; !ok {
+            context.reportError(location, "Value \"%s\" does not exist in \"%s\" enum.", enumValue.Value.Value, t.Name)
+        }
+    case *Scalar:
+        // user defined scalar without ParseLiteral accepts any literal
+        if t.ParseLiteral == nil {
+            return
+        }
+        if _, err := t.ParseLiteral(value); err != nil {
+            context.reportError(location, "Expected value of type \"%s\", found %s.", t.Name, getValueLiteral(value))
+        }
+    }
+}
+
+/**
+ * Argument Uniqueness
+ * Fields and directives treat arguments as a mapping of argument name to value.
+ */
+func uniqueArgumentNamesRule(context *validationContext) {
+    checkArguments := func(arguments []*frontend.Argument) {
+        known := make(map[string]bool, len(arguments))
+        for _, argument := range arguments {
+            if known[argument.Name.Value] {
+                context.reportError(argument.Location, "There can be only one argument named \"%s\".", argument.Name.Value)
+            }
+            known[argument.Name.Value] = true
+        }
+    }
+    context.walkDocument(func(parentType Type, selection frontend.Selection) {
+        if field, ok := selection.(*frontend.Field); ok {
+            checkArguments(field.Arguments)
+        }
+    })
+    context.walkDirectives(func(directives []*frontend.Directive, location string) {
+        for _, directive := range directives {
+            checkArguments(directive.Arguments)
+        }
+    })
+}
+
+/**
+ * Directives Are Defined
+ * GraphQL servers define what directives they support and where they support them.
+ */
+func knownDirectivesRule(context *validationContext) {
+    context.walkDirectives(func(directives []*frontend.Directive, location string) {
+        for _, directive := range directives {
+            directiveDefinition := context.getDirective(directive.Name.Value)
+            if directiveDefinition == nil {
+                context.reportError(directive.Location, "Unknown directive \"@%s\".", directive.Name.Value)
+                continue
+            }
+            if !directiveDefinition.HasLocation(location) {
+                context.reportError(directive.Location, "Directive \"@%s\" may not be used on %s.", directive.Name.Value, location)
+            }
+        }
+    })
+}
+
+/**
+ * Directives Are Unique Per Location
+ * Directives are used to describe some metadata or behavioral change on the definition they apply to.
+ */
+func uniqueDirectivesPerLocationRule(context *validationContext) {
+    context.walkDirectives(func(directives []*frontend.Directive, location string) {
+        known := make(map[string]bool, len(directives))
+        for _, directive := range directives {
+            if known[directive.Name.Value] {
+                context.reportError(directive.Location, "The directive \"@%s\" can only be used once at this location.", directive.Name.Value)
+            }
+            known[directive.Name.Value] = true
+        }
+    })
+}
+
+/**
+ * Fragment Name Uniqueness
+ * Fragment definitions are referenced in fragment spreads by name.
+ */
+func uniqueFragmentNamesRule(context *validationContext) {
+    known := make(map[string]bool, len(context.fragmentList))
+    for _, fragmentDefinition := range context.fragmentList {
+        fragmentName := fragmentDefinition.Name.Value
+        if known[fragmentName] {
+            context.reportError(fragmentDefinition.Name.Location, "There can be only one fragment named \"%s\".", fragmentName)
+        }
+        known[fragmentName] = true
+    }
+}
+
+/**
+ * Fragment spread target defined
+ * Named fragment spreads must refer to fragments defined within the document.
+ */
+func knownFragmentNamesRule(context *validationContext) {
+    context.walkDocument(func(parentType Type, selection frontend.Selection) {
+        fragmentSpread, ok := selection.(*frontend.FragmentSpread)
+        if !ok {
+            return
+        }
+        if _, ok := context.fragments[fragmentSpread.Name.Value]; !ok {
+            context.reportError(fragmentSpread.Name.Location, "Unknown fragment \"%s\".", fragmentSpread.Name.Value)
+        }
+    })
+}
+
+/**
+ * Fragments Must Be Used
+ * Defined fragments must be used within a document.
+ */
+func noUnusedFragmentsRule(context *validationContext) {
+    used := make(map[string]bool)
+    for _, operationDefinition := range context.operations {
+        for _, fragmentDefinition := range context.getRecursivelyReferencedFragments(operationDefinition.SelectionSet) {
+            used[fragmentDefinition.Name.Value] = true
+        }
+    }
+    for _, fragmentDefinition := range context.fragmentList {
+        if !used[fragmentDefinition.Name.Value] {
+            context.reportError(fragmentDefinition.Name.Location, "Fragment \"%s\" is never used.", fragmentDefinition.Name.Value)
+        }
+    }
+}
+
+/**
+ * Fragment spreads must not form cycles
+ * The graph of fragment spreads must not form any cycles including spreading itself.
+ */
+func noFragmentCyclesRule(context *validationContext) {
+    // fragments already checked, cycles through them have been reported
+    visited := make(map[string]bool)
+    var spreadPath []*frontend.FragmentSpread
+    spreadPathIndex := make(map[string]int)
+
+    var detectCycle func(fragmentDefinition *frontend.FragmentDefinition)
+    detectCycle = func(fragmentDefinition *frontend.FragmentDefinition) {
+        fragmentName := fragmentDefinition.Name.Value
+        if visited[fragmentName] {
+            return
+        }
+        visited[fragmentName] = true
+        spreadPathIndex[fragmentName] = len(spreadPath)
+        for _, fragmentSpread := range getFragmentSpreads(fragmentDefinition.SelectionSet) {
+            spreadName := fragmentSpread.Name.Value
+            cycleIndex, inPath := spreadPathIndex[spreadName]
+            spreadPath = append(spreadPath, fragmentSpread)
+            if !inPath {
+                if target, ok := context.fragments[spreadName]; ok {
+                    detectCycle(target)
+                }
+            } else {
+                via := ""
+                for _, spread := range spreadPath[cycleIndex:len(spreadPath)-1] {
+                    via += " \"" + spread.Name.Value + "\""
+                }
+                if via != "" {
+                    via = " via" + via
+                }
+                context.reportError(fragmentSpread.Name.Location, "Cannot spread fragment \"%s\" within itself%s.", spreadName, via)
+            }
+            spreadPath = spreadPath[:len(spreadPath)-1]
+        }
+        delete(spreadPathIndex, fragmentName)
+    }
+    for _, fragmentDefinition := range context.fragmentList {
+        detectCycle(fragmentDefinition)
+    }
+}
+
+/**
+ * Variable Uniqueness
+ * If any operation defines more than one variable with the same name, it is ambiguous and invalid.
+ */
+func uniqueVariableNamesRule(context *validationContext) {
+    for _, operationDefinition := range context.operations {
+        known := make(map[string]bool, len(operationDefinition.VariableDefinitions))
+        for _, variableDefinition := range operationDefinition.VariableDefinitions {
+            variableName := variableDefinition.Variable.Value
+            if known[variableName] {
+                context.reportError(variableDefinition.Location, "There can be only one variable named \"$%s\".", variableName)
+            }
+            known[variableName] = true
+        }
+    }
+}
+
+// get variables used by operation, include variables used in referenced fragments
+func (context *validationContext) getOperationVariableUsages(operationDefinition *frontend.OperationDefinition) []variableUsage {
+    usages := getVariableUsages(operationDefinition.SelectionSet)
+    for _, directive := range operationDefinition.Directives {
+        usages = append(usages, getArgumentsVariableUsages(directive.Arguments)...)
+    }
+    for _, fragmentDefinition := range context.getRecursivelyReferencedFragments(operationDefinition.SelectionSet) {
+        usages = append(usages, getVariableUsages(fragmentDefinition.SelectionSet)...)
+        for _, directive := range fragmentDefinition.Directives {
+            usages = append(usages, getArgumentsVariableUsages(directive.Arguments)...)
+        }
+    }
+    return usages
+}
+
+/**
+ * All Variable Uses Defined
+ * Variables are scoped on a per-operation basis, every variable used must be defined by the operation.
+ */
+func noUndefinedVariablesRule(context *validationContext) {
+    for _, operationDefinition := range context.operations {
+        defined := make(map[string]bool, len(operationDefinition.VariableDefinitions))
+        for _, variableDefinition := range operationDefinition.VariableDefinitions {
+            defined[variableDefinition.Variable.Value] = true
+        }
+        reported := make(map[string]bool)
+        for _, usage := range context.getOperationVariableUsages(operationDefinition) {
+            variableName := usage.variable.Value
+            if defined[variableName] || reported[variableName] {
+                continue
+            }
+            reported[variableName] = true
+            if operationDefinition.Name != nil {
+                context.reportError(usage.location, "Variable \"$%s\" is not defined by operation \"%s\".", variableName, operationDefinition.Name.Value)
+            } else {
+                context.reportError(usage.location, "Variable \"$%s\" is not defined.", variableName)
+            }
+        }
+    }
+}
+
+/**
+ * All Variables Used
+ * All variables defined by an operation must be used in that operation or a fragment transitively included by that operation.
+ */
+func noUnusedVariablesRule(context *validationContext) {
+    for _, operationDefinition := range context.operations {
+        used := make(map[string]bool)
+        for _, usage := range context.getOperationVariableUsages(operationDefinition) {
+            used[usage.variable.Value] = true
+        }
+        for _, variableDefinition := range operationDefinition.VariableDefinitions {
+            variableName := variableDefinition.Variable.Value
+            if used[variableName] {
+                continue
+            }
+            if operationDefinition.Name != nil {
+                context.reportError(variableDefinition.Location, "Variable \"$%s\" is never used in operation \"%s\".", variableName, operationDefinition.Name.Value)
+            } else {
+                context.reportError(variableDefinition.Location, "Variable \"$%s\" is never used.", variableName)
+            }
+        }
+    }
+}
+
+// get variables used by operation with the input types expected at their positions, include variables used in referenced fragments
+func (context *validationContext) getOperationTypedVariableUsages(operationDefinition *frontend.OperationDefinition) []variableUsage {
+    var usages []variableUsage
+    collectFromArguments := func(arguments []*frontend.Argument, argumentDefinitions *Arguments) {
+        if argumentDefinitions == nil {
+            return
+        }
+        for _, argument := range arguments {
+            if argumentDefinition, ok := (*argumentDefinitions)[argument.Name.Value]; ok {
+                usages = append(usages, getValueTypedVariableUsages(argument.Value, argumentDefinition.Type, argumentDefinition.DefaultValue != nil)...)
+            }
+        }
+    }
+    collectFromDirectives := func(directives []*frontend.Directive) {
+        for _, directive := range directives {
+            if directiveDefinition := context.getDirective(directive.Name.Value); directiveDefinition != nil {
+                collectFromArguments(directive.Arguments, directiveDefinition.Arguments)
+            }
+        }
+    }
+    visit := func(parentType Type, selection frontend.Selection) {
+        switch s := selection.(type) {
+        case *frontend.Field:
+            if objectField := getFieldDefinition(&context.schema, parentType, s.Name.Value); objectField != nil {
+                collectFromArguments(s.Arguments, objectField.Arguments)
+            }
+            collectFromDirectives(s.Directives)
+        case *frontend.FragmentSpread:
+            collectFromDirectives(s.Directives)
+        case *frontend.InlineFragment:
+            collectFromDirectives(s.Directives)
+        }
+    }
+    collectFromDirectives(operationDefinition.Directives)
+    context.walkSelectionSet(context.getOperationRootType(operationDefinition), operationDefinition.SelectionSet, visit)
+    for _, fragmentDefinition := range context.getRecursivelyReferencedFragments(operationDefinition.SelectionSet) {
+        collectFromDirectives(fragmentDefinition.Directives)
+        context.walkSelectionSet(context.getTypeByName(fragmentDefinition.TypeCondition), fragmentDefinition.SelectionSet, visit)
+    }
+    return usages
+}
+
+/**
+ * All Variable Usages Are Allowed
+ * Variable usages must be compatible with the arguments they are passed to, a nullable variable is only
+ * allowed in non-null position when the variable or the position has a non-null default value.
+ */
+func variablesInAllowedPositionRule(context *validationContext) {
+    for _, operationDefinition := range context.operations {
+        variableDefinitions := make(map[string]*frontend.VariableDefinition, len(operationDefinition.VariableDefinitions))
+        for _, variableDefinition := range operationDefinition.VariableDefinitions {
+            if _, ok := variableDefinitions[variableDefinition.Variable.Value]; !ok {
+                variableDefinitions[variableDefinition.Variable.Value] = variableDefinition
+            }
+        }
+        for _, usage := range context.getOperationTypedVariableUsages(operationDefinition) {
+            // undefined variable is reported by noUndefinedVariablesRule
+            variableDefinition, ok := variableDefinitions[usage.variable.Value]
+            if !ok {
+                continue
+            }
+            variableType, err := getVariableType(&context.schema, variableDefinition.Type)
+            if err != nil {
+                continue
+            }
+            if !isVariableUsageAllowed(variableDefinition, variableType, usage) {
+                context.reportError(usage.location, "Variable \"$%s\" of type \"%s\" used in position expecting type \"%s\".", usage.variable.Value, variableType.GetName(), usage.inputType.GetName())
+            }
+        }
+    }
+}
+
+func isVariableUsageAllowed(variableDefinition *frontend.VariableDefinition, variableType Type, usage variableUsage) bool {
+    locationNonNull, isLocationNonNull := usage.inputType.(*NonNull)
+    _, isVariableNonNull := variableType.(*NonNull)
+    if isLocationNonNull && !isVariableNonNull {
+        _, isNullDefault := variableDefinition.DefaultValue.(frontend.NullValue)
+        hasNonNullDefault := variableDefinition.DefaultValue != nil && !isNullDefault
+        if !hasNonNullDefault && !usage.hasDefault {
+            return false
+        }
+        return isTypeSubTypeOf(variableType, locationNonNull.OfType)
+    }
+    return isTypeSubTypeOf(variableType, usage.inputType)
+}
+
+type collectedField struct {
+    parentType    Type
+    field        *frontend.Field
+    // parent types from root to this field, the last one is parentType
+    parentTypes []Type
+    // fragments spread from root to this field
+    fragments   []string
+}
+
+// SelectionSet with it's parent type, parentTypes are the parent types of enclosing fields
+type scopedSelectionSet struct {
+    parentType     Type
+    selectionSet  *frontend.SelectionSet
+    parentTypes  []Type
+    fragments    []string
+}
+
+func containsFragment(fragments []string, fragmentName string) bool {
+    for _, name := range fragments {
+        if name == fragmentName {
+            return true
+        }
+    }
+    return false
+}
+
+/**
+ * collect Fields by response key from selectionSet, Fields in fragments are collected into same group.
+ * visitedFragments skips fragment spread twice in same SelectionSet, fragment already spread by enclosing
+ * fields is skipped too, so fragment cycle through field (e.g. fragment A on T { f { ...A } }) terminates.
+ */
+func (context *validationContext) collectFieldsByResponseKey(scope scopedSelectionSet, visitedFragments map[string]bool, responseKeys []string, groupedFields map[string][]collectedField) ([]string, map[string][]collectedField) {
+    parentType := scope.parentType
+    for _, selection := range scope.selectionSet.GetSelections() {
+        switch s := selection.(type) {
+        case *frontend.Field:
+            responseKey := getResponseKey(s)
+            if _, ok := groupedFields[responseKey]; !ok {
+                responseKeys = append(responseKeys, responseKey)
+            }
+            parentTypes := append(append([]Type{}, scope.parentTypes...), parentType)
+            groupedFields[responseKey] = append(groupedFields[responseKey], collectedField{parentType, s, parentTypes, scope.fragments})
+        case *frontend.FragmentSpread:
+            fragmentDefinition, ok := context.fragments[s.Name.Value]
+            if !ok || visitedFragments[s.Name.Value] || containsFragment(scope.fragments, s.Name.Value) {
+                continue
+            }
+            visitedFragments[s.Name.Value] = true
+            fragments := append(append([]string{}, scope.fragments...), s.Name.Value)
+            fragmentScope := scopedSelectionSet{context.getTypeByName(fragmentDefinition.TypeCondition), fragmentDefinition.SelectionSet, scope.parentTypes, fragments}
+            responseKeys, groupedFields = context.collectFieldsByResponseKey(fragmentScope, visitedFragments, responseKeys, groupedFields)
+        case *frontend.InlineFragment:
+            fragmentType := parentType
+            if s.TypeCondition != nil {
+                fragmentType = context.getTypeByName(s.TypeCondition)
+            }
+            fragmentScope := scopedSelectionSet{fragmentType, s.SelectionSet, scope.parentTypes, scope.fragments}
+            responseKeys, groupedFields = context.collectFieldsByResponseKey(fragmentScope, visitedFragments, responseKeys, groupedFields)
+        }
+    }
+    return responseKeys, groupedFields
+}
+
+// check two Fields are never executed at same time, that is they are under different Object types at any level
+func areMutuallyExclusive(a collectedField, b collectedField) bool {
+    for i := 0; i < len(a.parentTypes) && i < len(b.parentTypes); i++ {
+        _, aIsObject := a.parentTypes[i].(*Object)
+        _, bIsObject := b.parentTypes[i].(*Object)
+        if aIsObject && bIsObject && a.parentTypes[i] != b.parentTypes[i] {
+            return true
+        }
+    }
+    return false
+}
+
+// get conflict reason of two same response key Fields, empty string means they can be merged
+func (context *validationContext) getFieldConflictReason(a collectedField, b collectedField) string {
+    // mutually exclusive fields may be different fields, but they must have the same response shape
+    if !areMutuallyExclusive(a, b) {
+        if getFieldName(a.field) != getFieldName(b.field) {
+            return fmt.Sprintf("\"%s\" and \"%s\" are different fields", getFieldName(a.field), getFieldName(b.field))
+        }
+        if !argumentsAreEqual(a.field.Arguments, b.field.Arguments) {
+            return "they have differing arguments"
+        }
+    }
+    objectFieldA := getFieldDefinition(&context.schema, a.parentType, getFieldName(a.field))
+    objectFieldB := getFieldDefinition(&context.schema, b.parentType, getFieldName(b.field))
+    if objectFieldA != nil && objectFieldB != nil && doTypesConflict(objectFieldA.Type, objectFieldB.Type) {
+        return fmt.Sprintf("they return conflicting types \"%s\" and \"%s\"", objectFieldA.Type.GetName(), objectFieldB.Type.GetName())
+    }
+    return ""
+}
+
+func argumentsAreEqual(a []*frontend.Argument, b []*frontend.Argument) bool {
+    if len(a) != len(b) {
+        return false
+    }
+    values := make(map[string]string, len(a))
+    for _, argument := range a {
+        values[argument.Name.Value] = getValueLiteral(argument.Value)
+    }
+    for _, argument := range b {
+        if value, ok := values[argument.Name.Value]; !ok || value != getValueLiteral(argument.Value) {
+            return false
+        }
+    }
+    return true
+}
+
+// get GraphQL literal of Value for comparison and error message, e.g. {id: $id, tags: ["a"]}
+func getValueLiteral(value frontend.Value) string {
+    switch v := value.(type) {
+    case frontend.Variable:
+        return "$" + v.Value
+    case frontend.IntValue:
+        return fmt.Sprintf("%d", v.Value)
+    case frontend.FloatValue:
+        return fmt.Sprintf("%v", v.Value)
+    case frontend.StringValue:
+        return fmt.Sprintf("%q", v.Value)
+    case frontend.BooleanValue:
+        return fmt.Sprintf("%v", v.Value)
+    case frontend.NullValue:
+        return "null"
+    case frontend.EnumValue:
+        return v.Value.Value
+    case frontend.ListValue:
+        items := make([]string, 0, len(v.Value))
+        for _, item := range v.Value {
+            items = append(items, getValueLiteral(item))
+        }
+        return "[" + strings.Join(items, ", ") + "]"
+    case frontend.ObjectValue:
+        objectFields := make([]string, 0, len(v.Value))
+        for _, objectField := range v.Value {
+            objectFields = append(objectFields, objectField.Name.Value + ": " + getValueLiteral(objectField.Value))
+        }
+        return "{" + strings.Join(objectFields, ", ") + "}"
+    }
+    return ""
+}
+
+/**
+ * Field Selection Merging
+ * If multiple field selections with the same response key are encountered during execution,
+ * the field and arguments to execute and the resulting value should be unambiguous.
+ */
+func overlappingFieldsCanBeMergedRule(context *validationContext) {
+    // fragments are checked both at definition and at spread, report once
+    reported := make(map[string]bool)
+    var checkFieldConflicts func(scopes []scopedSelectionSet)
+    checkFieldConflicts = func(scopes []scopedSelectionSet) {
+        var responseKeys []string
+        groupedFields  := make(map[string][]collectedField)
+        visitedFragments := make(map[string]bool)
+        for _, scope := range scopes {
+            responseKeys, groupedFields = context.collectFieldsByResponseKey(scope, visitedFragments, responseKeys, groupedFields)
+        }
+        for _, responseKey := range responseKeys {
+            fields := groupedFields[responseKey]
+            conflicted := false
+            for i := 0; i < len(fields) && !conflicted; i++ {
+                for _, other := range fields[i+1:] {
+                    reason := context.getFieldConflictReason(fields[i], other)
+                    if reason == "" {
+                        continue
+                    }
+                    conflicted = true
+                    message := fmt.Sprintf("Fields \"%s\" conflict because %s. Use different aliases on the fields to fetch both if this was intentional.", responseKey, reason)
+                    reportKey := fmt.Sprintf("%d:%s", other.field.Location.Offset, message)
+                    if !reported[reportKey] {
+                        reported[reportKey] = true
+                        context.reportError(other.field.Location, "%s", message)
+                    }
+                    break
+                }
+            }
+            if conflicted {
+                continue
+            }
+            // sub SelectionSets of same response key will be merged at execution, check them together
+            var subScopes []scopedSelectionSet
+            for _, collected := range fields {
+                if collected.field.SelectionSet == nil {
+                    continue
+                }
+                var subType Type
+                if objectField := getFieldDefinition(&context.schema, collected.parentType, getFieldName(collected.field)); objectField != nil {
+                    subType = getNamedType(objectField.Type)
+                }
+                subScopes = append(subScopes, scopedSelectionSet{subType, collected.field.SelectionSet, collected.parentTypes, collected.fragments})
+            }
+            if len(subScopes) > 0 {
+                checkFieldConflicts(subScopes)
+            }
+        }
+    }
+    for _, operationDefinition := range context.operations {
+        checkFieldConflicts([]scopedSelectionSet{{context.getOperationRootType(operationDefinition), operationDefinition.SelectionSet, nil, nil}})
+    }
+    for _, fragmentDefinition := range context.fragmentList {
+        checkFieldConflicts([]scopedSelectionSet{{context.getTypeByName(fragmentDefinition.TypeCondition), fragmentDefinition.SelectionSet, nil, nil}})
+    }
+}
+
+/**
+ * Input Object Field Uniqueness
+ * Input objects must not contain more than one field of the same name.
+ */
+func uniqueInputFieldNamesRule(context *validationContext) {
+    var checkValue func(value frontend.Value)
+    checkValue = func(value frontend.Value) {
+        switch v := value.(type) {
+        case frontend.ListValue:
+            for _, item := range v.Value {
+                checkValue(item)
+            }
+        case frontend.ObjectValue:
+            known := make(map[string]bool, len(v.Value))
+            for _, objectField := range v.Value {
+                if known[objectField.Name.Value] {
+                    context.reportError(objectField.Location, "There can be only one input field named \"%s\".", objectField.Name.Value)
+                }
+                known[objectField.Name.Value] = true
+                checkValue(objectField.Value)
+            }
+        }
+    }
+    context.walkArguments(func(arguments []*frontend.Argument, argumentDefinitions *Arguments) {
+        for _, argument := range arguments {
+            checkValue(argument.Value)
+        }
+    })
+    for _, operationDefinition := range context.operations {
+        for _, variableDefinition := range operationDefinition.VariableDefinitions {
+            checkValue(variableDefinition.DefaultValue)
+        }
+    }
+}
@@ -0,0 +1,354 @@
+// validator_test.go
+
+package backend
+
+import (
+    "fast-graphql/src/frontend"
+    "fmt"
+    "testing"
+    "time"
+)
+
+var validatorTestSDL = `
+type Query {
+  echo(s: String!): String
+  opt(s: String = "x"): String
+  add(input: AddInput!): Int
+  ids(list: [Int!]): Int
+  kind(k: Kind): Kind
+  pet: Pet
+  pets: [Pet]
+  dog: Dog
+  search: [SearchResult]
+  t: T
+  limit(n: Int! = 10): Int
+}
+
+type T {
+  f: T
+}
+
+type Mutation {
+  like(id: ID!): Boolean
+}
+
+input AddInput {
+  a: Int!
+  b: [Int]
+  k: Kind
+  c: Int = 1
+}
+
+enum Kind {
+  A
+  B
+}
+
+interface Pet {
+  name: String
+}
+
+type Dog implements Pet {
+  name: String
+  size: Int
+  barks: Boolean
+  owner: Person
+}
+
+type Cat implements Pet {
+  name: String
+  size: String
+  owner: Person
+}
+
+type Person {
+  name: String
+  age: Int
+}
+
+union SearchResult = Dog | Person
+`
+
+/**
+ * Validation tests
+ * every case lists all expected errors in reported order, formatted as "message (line:column)",
+ * an empty list means the document is valid.
+ */
+var validatorTests = []struct {
+    name   string
+    query  string
+    errors []string
+}{
+    // valid documents
+    {"simple query", `{ echo(s: "a") }`, nil},
+    {"variables", `query Q($s: String!, $k: Kind = A) { echo(s: $s) kind(k: $k) }`, nil},
+    {"fragments", `{ pet { ...P ... on Dog { size } } } fragment P on Pet { name }`, nil},
+    {"input object", `query Q($a: Int!) { add(input: {a: $a, b: [1, 2], k: B}) }`, nil},
+    {"list accepts single item", `{ ids(list: 1) add(input: {a: 1, b: 2}) }`, nil},
+    {"nullable variable with default", `query Q($s: String = "a") { echo(s: $s) }`, nil},
+    {"variable in position with default", `query Q($s: String) { opt(s: $s) }`, nil},
+    {"same fields on different objects", `{ pet { ... on Dog { name } ... on Cat { name } } }`, nil},
+    {"same shape on different objects", `{ pet { ... on Dog { x: name } ... on Cat { x: name } } }`, nil},
+    {"introspection", `{ __typename __type(name: "Dog") { name } __schema { queryType { name } } }`, nil},
+
+    // Executable Definitions
+    {"type definition", `{ opt } type T { a: Int }`, []string{
+        `The ObjectTypeDefinition definition is not executable. (1:9)`,
+    }},
+    // Operation Name Uniqueness
+    {"duplicated operation", `query Q { opt } query Q { opt }`, []string{
+        `There can be only one operation named "Q". (1:17)`,
+    }},
+    // Lone Anonymous Operation
+    {"anonymous operation with others", `{ opt } query Q { opt }`, []string{
+        `This anonymous operation must be the only defined operation. (1:1)`,
+    }},
+    // Known Type Names
+    {"unknown type", `query Q($x: Foo) { opt } fragment F on Bar { a } { ... on Baz { a } }`, []string{
+        `This anonymous operation must be the only defined operation. (1:50)`,
+        `Unknown type "Foo". (1:9)`,
+        `Unknown type "Bar". (1:40)`,
+        `Unknown type "Baz". (1:59)`,
+        `Fragment "F" is never used. (1:35)`,
+        `Variable "$x" is never used in operation "Q". (1:9)`,
+    }},
+    {"Bool is not a built-in type", `query Q($b: Bool) { opt @include(if: $b) }`, []string{
+        `Unknown type "Bool". (1:9)`,
+    }},
+    // Fragments On Composite Types
+    {"fragment on scalar", `{ pet { ...F } } fragment F on Int { a }`, []string{
+        `Fragment "F" cannot condition on non composite type "Int". (1:27)`,
+    }},
+    // Fragment Spread Is Possible
+    {"impossible spread", `{ dog { ... on Cat { name } } }`, []string{
+        `Fragment cannot be spread here as objects of type "Dog" can never be of type "Cat". (1:9)`,
+    }},
+    // Variables Are Input Types
+    {"output type variable", `query Q($d: Dog) { opt }`, []string{
+        `Variable "$d" cannot be non-input type "Dog". (1:9)`,
+        `Variable "$d" is never used in operation "Q". (1:9)`,
+    }},
+    // Field Selections
+    {"unknown field", `{ dog { name color } }`, []string{
+        `Cannot query field "color" on type "Dog". (1:14)`,
+    }},
+    {"schema meta-field on non-root", `{ dog { __schema { queryType { name } } } }`, []string{
+        `Cannot query field "__schema" on type "Dog". (1:9)`,
+    }},
+    // Leaf Field Selections
+    {"leaf selections", `{ opt { a } dog }`, []string{
+        `Field "opt" must not have a selection since type "String" has no subfields. (1:3)`,
+        `Field "dog" of type "Dog" must have a selection of subfields. (1:13)`,
+    }},
+    // Argument Names
+    {"unknown argument", `{ opt(x: 1) @skip(unless: true, if: true) }`, []string{
+        `Unknown argument "unless" on directive "@skip". (1:19)`,
+        `Unknown argument "x" on field "opt" of type "Query". (1:7)`,
+    }},
+    // Required Arguments
+    {"missing required argument", `{ echo opt @include limit }`, []string{
+        `Directive "@include" argument "if" of type "Boolean!" is required, but it was not provided. (1:12)`,
+        `Field "echo" argument "s" of type "String!" is required, but it was not provided. (1:3)`,
+    }},
+    // Values of Correct Type
+    {"wrong scalar value", `{ echo(s: 1) ids(list: [1, "2"]) }`, []string{
+        `Expected value of type "String", found 1. (1:11)`,
+        `Expected value of type "Int", found "2". (1:28)`,
+    }},
+    {"wrong input object value", `{ add(input: {b: ["x"], k: C, z: 1}) }`, []string{
+        `Expected value of type "Int", found "x". (1:19)`,
+        `Value "C" does not exist in "Kind" enum. (1:28)`,
+        `Field "z" is not defined by type "AddInput". (1:31)`,
+        `Field "AddInput.a" of required type "Int!" was not provided. (1:14)`,
+    }},
+    {"non-object for input object", `{ add(input: 3) }`, []string{
+        `Expected value of type "AddInput", found 3. (1:14)`,
+    }},
+    {"non-enum for enum", `{ kind(k: "A") }`, []string{
+        `Enum "Kind" cannot represent non-enum value: "A". (1:11)`,
+    }},
+    {"null for non-null argument", `{ echo(s: null) limit(n: null) opt @include(if: null) }`, []string{
+        `Expected value of type "String!", found null. (1:11)`,
+        `Expected value of type "Int!", found null. (1:26)`,
+        `Expected value of type "Boolean!", found null. (1:49)`,
+    }},
+    {"null in non-null list item", `{ ids(list: [1, null]) }`, []string{
+        `Expected value of type "Int!", found null. (1:17)`,
+    }},
+    {"wrong variable default value", `query Q($k: Kind = C) { kind(k: $k) }`, []string{
+        `Value "C" does not exist in "Kind" enum. (1:20)`,
+    }},
+    // Argument Uniqueness
+    {"duplicated argument", `{ opt(s: "a", s: "b") }`, []string{
+        `There can be only one argument named "s". (1:15)`,
+    }},
+    // Directives
+    {"unknown and misplaced directive", `query Q @skip(if: true) { opt @foo }`, []string{
+        `Directive "@skip" may not be used on QUERY. (1:9)`,
+        `Unknown directive "@foo". (1:31)`,
+    }},
+    {"duplicated directive", `{ opt @skip(if: true) @skip(if: false) }`, []string{
+        `The directive "@skip" can only be used once at this location. (1:23)`,
+    }},
+    // Fragments
+    {"duplicated fragment", `{ pet { ...F } } fragment F on Pet { name } fragment F on Pet { name }`, []string{
+        `There can be only one fragment named "F". (1:54)`,
+    }},
+    {"unknown fragment", `{ pet { ...F } }`, []string{
+        `Unknown fragment "F". (1:12)`,
+    }},
+    {"unused fragment", `{ opt } fragment F on Pet { name }`, []string{
+        `Fragment "F" is never used. (1:18)`,
+    }},
+    {"fragment cycle", `{ pet { ...A } } fragment A on Pet { ...B } fragment B on Pet { ...A }`, []string{
+        `Cannot spread fragment "A" within itself via "B". (1:68)`,
+    }},
+    {"fragment cycle through field", `{ t { ...A } } fragment A on T { f { ...A } }`, []string{
+        `Cannot spread fragment "A" within itself. (1:41)`,
+    }},
+    // Variables
+    {"duplicated variable", `query Q($s: String, $s: String) { opt(s: $s) }`, []string{
+        `There can be only one variable named "$s". (1:21)`,
+    }},
+    {"undefined variable", `query Q { opt(s: $s) }`, []string{
+        `Variable "$s" is not defined by operation "Q". (1:18)`,
+    }},
+    {"unused variable", `query Q($s: String) { opt }`, []string{
+        `Variable "$s" is never used in operation "Q". (1:9)`,
+    }},
+    // All Variable Usages Are Allowed
+    {"nullable variable in non-null position", `query Q($s: String) { echo(s: $s) }`, []string{
+        `Variable "$s" of type "String" used in position expecting type "String!". (1:31)`,
+    }},
+    {"variable in input object field", `query Q($a: Int) { add(input: {a: $a}) }`, []string{
+        `Variable "$a" of type "Int" used in position expecting type "Int!". (1:35)`,
+    }},
+    {"variable in list item", `query Q($a: Int) { ids(list: [$a]) }`, []string{
+        `Variable "$a" of type "Int" used in position expecting type "Int!". (1:31)`,
+    }},
+    {"variable of wrong type", `query Q($a: Int!) { opt(s: $a) }`, []string{
+        `Variable "$a" of type "Int!" used in position expecting type "String". (1:28)`,
+    }},
+    {"variable in fragment", `query Q($b: Boolean) { pet { ...F } } fragment F on Pet { name @skip(if: $b) }`, []string{
+        `Variable "$b" of type "Boolean" used in position expecting type "Boolean!". (1:74)`,
+    }},
+    // Field Selection Merging
+    {"different fields", `{ a: echo(s: "a") a: opt }`, []string{
+        `Fields "a" conflict because "echo" and "opt" are different fields. Use different aliases on the fields to fetch both if this was intentional. (1:19)`,
+    }},
+    {"differing arguments", `{ opt(s: "a") opt(s: "b") }`, []string{
+        `Fields "opt" conflict because they have differing arguments. Use different aliases on the fields to fetch both if this was intentional. (1:15)`,
+    }},
+    {"conflicting return types", `{ pet { ... on Dog { size } ... on Cat { size } } }`, []string{
+        `Fields "size" conflict because they return conflicting types "Int" and "String". Use different aliases on the fields to fetch both if this was intentional. (1:42)`,
+    }},
+    {"conflicting nested return types", `{ pet { ... on Dog { owner { n: name } } ... on Cat { owner { n: age } } } }`, []string{
+        `Fields "n" conflict because they return conflicting types "String" and "Int". Use different aliases on the fields to fetch both if this was intentional. (1:63)`,
+    }},
+    {"conflicting list shape", `{ search { ... on Dog { name } } search: pets { name } }`, []string{
+        `Fields "search" conflict because "search" and "pets" are different fields. Use different aliases on the fields to fetch both if this was intentional. (1:34)`,
+    }},
+    // Input Object Field Uniqueness
+    {"duplicated input field", `{ add(input: {a: 1, a: 2}) }`, []string{
+        `There can be only one input field named "a". (1:21)`,
+    }},
+}
+
+func buildValidatorTestSchema(t *testing.T) Schema {
+    schema, err := BuildSchema(validatorTestSDL, map[string]ResolveFunction{
+        "Pet.__resolveType": func(p ResolveParams) (interface{}, error) {
+            return "Dog", nil
+        },
+        "SearchResult.__resolveType": func(p ResolveParams) (interface{}, error) {
+            return "Dog", nil
+        },
+    })
+    if err != nil {
+        t.Fatalf("BuildSchema() error: %v", err)
+    }
+    return schema
+}
+
+// run rules in goroutine, a rule which never returns fails the test instead of blocking it
+func validateWithTimeout(t *testing.T, schema Schema, document *frontend.Document, rules []validationRule) []*ErrorInfo {
+    done := make(chan []*ErrorInfo, 1)
+    go func() {
+        context := newValidationContext(schema, document)
+        for _, rule := range rules {
+            rule(context)
+        }
+        done <- context.errors
+    }()
+    select {
+    case errors := <-done:
+        return errors
+    case <-time.After(5 * time.Second):
+        t.Fatalf("validation of %q did not return.", frontend.PrintCompact(document))
+        return nil
+    }
+}
+
+func TestValidate(t *testing.T) {
+    schema := buildValidatorTestSchema(t)
+    for _, test := range validatorTests {
+        document, err := frontend.Compile(test.query)
+        if err != nil {
+            t.Errorf("%s: Compile() error: %v", test.name, err)
+            continue
+        }
+        var messages []string
+        for _, errorInfo := range validateWithTimeout(t, schema, document, specifiedRules) {
+            location := errorInfo.Locations[0]
+            messages = append(messages, fmt.Sprintf("%s (%d:%d)", errorInfo.Message, location.Line, location.Col))
+        }
+        if len(messages) != len(test.errors) {
+            t.Errorf("%s: got %d errors, want %d:\n    got  %q\n    want %q", test.name, len(messages), len(test.errors), messages, test.errors)
+            continue
+        }
+        for i := range messages {
+            if messages[i] != test.errors[i] {
+                t.Errorf("%s: error %d:\n    got  %s\n    want %s", test.name, i, messages[i], test.errors[i])
+            }
+        }
+    }
+}
+
+// every rule must terminate on fragment cycles, no rule relies on noFragmentCyclesRule running first
+func TestValidateRulesTerminateOnFragmentCycles(t *testing.T) {
+    schema := buildValidatorTestSchema(t)
+    queries := []string{
+        `{ t { ...A } } fragment A on T { f { ...A } }`,
+        `{ t { ...A } } fragment A on T { ...A }`,
+        `{ t { ...A } } fragment A on T { f { ...B } } fragment B on T { ... on T { f { ...A } } }`,
+        `query Q($v: Boolean) { t { ...A } } fragment A on T { f @include(if: $v) { x: f { ...A } } x: f { ...A } }`,
+    }
+    for _, query := range queries {
+        document, err := frontend.Compile(query)
+        if err != nil {
+            t.Fatalf("Compile(%q) error: %v", query, err)
+        }
+        for _, rule := range specifiedRules {
+            validateWithTimeout(t, schema, document, []validationRule{rule})
+        }
+    }
+}
+
+// validation rejects invalid arguments before any ResolveFunction runs
+func TestExecuteRejectsInvalidDocument(t *testing.T) {
+    var resolved bool
+    schema, err := BuildSchema(`type Query { echo(s: String!): String }`, map[string]ResolveFunction{
+        "Query.echo": func(p ResolveParams) (interface{}, error) {
+            resolved = true
+            return p.Arguments["s"], nil
+        },
+    })
+    if err != nil {
+        t.Fatalf("BuildSchema() error: %v", err)
+    }
+    for _, query := range []string{`{ echo(s: 1) }`, `query Q($s: String) { echo(s: $s) }`} {
+        result := Execute(Request{Schema: schema, Query: query})
+        if resolved || result.Data != nil || len(result.Errors) != 1 {
+            t.Errorf("%s: want one validation error and no data, got data %v, errors %d, resolved %v", query, result.Data, len(result.Errors), resolved)
+        }
+    }
+}
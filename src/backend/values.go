@@ -0,0 +1,437 @@
+// values.go
+package backend
+
+import (
+    "fast-graphql/src/frontend"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "reflect"
+    "strconv"
+)
+
+/**
+ * Input Values
+ * Input values of arguments come from literal Value in document or JSON decoded request.Variables,
+ * they are coerced by the schema defined input type:
+ *     Scalar      -> Go value by Scalar.ParseLiteral or Scalar.ParseValue, e.g. Int accepted for Float
+ *     Enum        -> schema defined enum value
+ *     InputObject -> map[string]interface{}, missing fields filled by DefaultValue
+ *     List        -> []interface{}
+ *     NonNull     -> coerced value of wrapped type, null is not allowed
+ */
+
+// input coercion error with path of the invalid value, e.g. "input.tags[1]"
+type inputPathError struct {
+    path string
+    err  error
+}
+
+func (pathError *inputPathError) Error() string {
+    return pathError.err.Error()
+}
+
+// prepend path segment (".name" or "[index]") to input coercion error
+func withInputPath(err error, segment string) error {
+    if pathError, ok := err.(*inputPathError); ok {
+        return &inputPathError{segment+pathError.path, pathError.err}
+    }
+    return &inputPathError{segment, err}
+}
+
+// get path of input coercion error, empty if error is not on nested value
+func getInputPath(err error) string {
+    if pathError, ok := err.(*inputPathError); ok {
+        return pathError.path
+    }
+    return ""
+}
+
+// get variable value by the variable's own name (e.g. "userId" for `id: $userId`), false if not provided
+func getVariableValue(g *GlobalVariables, variable frontend.Variable) (interface{}, bool) {
+    variableValue, ok := g.QueryVariablesMap[variable.Value]
+    return variableValue, ok
+}
+
+// check Value is a Variable which is not provided by request
+func isMissingVariable(g *GlobalVariables, value frontend.Value) bool {
+    variable, ok := value.(frontend.Variable)
+    if !ok {
+        return false
+    }
+    _, provided := getVariableValue(g, variable)
+    return !provided
+}
+
+// coerce literal Value from document by input type, variables are picked from GlobalVariables.QueryVariablesMap
+func coerceLiteralValue(g *GlobalVariables, value frontend.Value, targetType Type) (interface{}, error) {
+    // variable value is already coerced by VariableDefinition type
+    if variable, ok := value.(frontend.Variable); ok {
+        variableValue, _ := getVariableValue(g, variable)
+        if _, ok := targetType.(*NonNull); ok && variableValue == nil {
+            err := "coerceLiteralValue(): expected non-nullable type \""+targetType.GetName()+"\" not to be null, but variable $"+variable.Value+" is null."
+            return nil, errors.New(err)
+        }
+        return variableValue, nil
+    }
+    if nonNull, ok := targetType.(*NonNull); ok {
+        if _, isNull := value.(frontend.NullValue); isNull {
+            err := "coerceLiteralValue(): expected non-nullable type \""+targetType.GetName()+"\" not to be null."
+            return nil, errors.New(err)
+        }
+        coercedValue, err := coerceLiteralValue(g, value, nonNull.OfType)
+        if err == nil && coercedValue == nil {
+            err = errors.New("coerceLiteralValue(): expected non-nullable type \""+targetType.GetName()+"\" not to be null.")
+        }
+        return coercedValue, err
+    }
+    if _, ok := value.(frontend.NullValue); ok {
+        return nil, nil
+    }
+
+    switch t := targetType.(type) {
+    case *List:
+        listValue, ok := value.(frontend.ListValue)
+        if !ok {
+            // input coercion of list accepts single item
+            item, err := coerceLiteralValue(g, value, t.Payload)
+            if err != nil {
+                return nil, err
+            }
+            return []interface{}{item}, nil
+        }
+        coercedList := make([]interface{}, 0, len(listValue.Value))
+        for _, itemValue := range listValue.Value {
+            item, err := coerceLiteralValue(g, itemValue, t.Payload)
+            if err != nil {
+                return nil, err
+            }
+            coercedList = append(coercedList, item)
+        }
+        return coercedList, nil
+    case *InputObject:
+        objectValue, ok := value.(frontend.ObjectValue)
+        if !ok {
+            err := "coerceLiteralValue(): input object \""+t.Name+"\" expects an object value."
+            return nil, errors.New(err)
+        }
+        coercedObject := make(map[string]interface{}, len(t.Fields))
+        for _, objectField := range objectValue.Value {
+            fieldName := objectField.Name.Value
+            inputObjectField, ok := t.Fields[fieldName]
+            if !ok {
+                err := "coerceLiteralValue(): field \""+fieldName+"\" is not defined by input object \""+t.Name+"\"."
+                return nil, errors.New(err)
+            }
+            // field referenced variable not provided is omitted, DefaultValue is used if exists
+            if isMissingVariable(g, objectField.Value) {
+                continue
+            }
+            fieldValue, err := coerceLiteralValue(g, objectField.Value, inputObjectField.Type)
+            if err != nil {
+                return nil, err
+            }
+            coercedObject[fieldName] = fieldValue
+        }
+        if err := fillInputObjectDefaultValues(t, coercedObject); err != nil {
+            return nil, err
+        }
+        return coercedObject, nil
+    case *Enum:
+        enumValue, ok := value.(frontend.EnumValue)
+        if !ok {
+            err := "coerceLiteralValue(): enum \""+t.Name+"\" cannot represent non-enum value."
+            return nil, errors.New(err)
+        }
+        return coerceVariableValue(enumValue.Value.Value, t)
+    case *Scalar:
+        if t.ParseLiteral != nil {
+            return t.ParseLiteral(value)
+        }
+        // user defined scalar without ParseLiteral, use Go value of literal
+        return valueFromLiteral(g, value)
+    }
+    err := "coerceLiteralValue(): type \""+targetType.GetName()+"\" is not an input type."
+    return nil, errors.New(err)
+}
+
+// convert literal Value to Go value without input type, variables in list and object are substituted
+func valueFromLiteral(g *GlobalVariables, value frontend.Value) (interface{}, error) {
+    switch v := value.(type) {
+    case frontend.Variable:
+        variableValue, _ := getVariableValue(g, v)
+        return variableValue, nil
+    case frontend.IntValue:
+        return v.Value, nil
+    case frontend.FloatValue:
+        return v.Value, nil
+    case frontend.StringValue:
+        return v.Value, nil
+    case frontend.BooleanValue:
+        return v.Value, nil
+    case frontend.NullValue:
+        return nil, nil
+    case frontend.EnumValue:
+        return v.Value.Value, nil
+    case frontend.ListValue:
+        list := make([]interface{}, 0, len(v.Value))
+        for _, itemValue := range v.Value {
+            item, err := valueFromLiteral(g, itemValue)
+            if err != nil {
+                return nil, err
+            }
+            list = append(list, item)
+        }
+        return list, nil
+    case frontend.ObjectValue:
+        object := make(map[string]interface{}, len(v.Value))
+        for _, objectField := range v.Value {
+            if isMissingVariable(g, objectField.Value) {
+                continue
+            }
+            fieldValue, err := valueFromLiteral(g, objectField.Value)
+            if err != nil {
+                return nil, err
+            }
+            object[objectField.Name.Value] = fieldValue
+        }
+        return object, nil
+    }
+    err := "valueFromLiteral(): illegal value type '"+reflect.TypeOf(value).Name()+"'."
+    return nil, errors.New(err)
+}
+
+// coerce JSON decoded variable value by input type
+func coerceVariableValue(value interface{}, targetType Type) (interface{}, error) {
+    if nonNull, ok := targetType.(*NonNull); ok {
+        if value == nil {
+            err := "coerceVariableValue(): expected non-nullable type \""+targetType.GetName()+"\" not to be null."
+            return nil, errors.New(err)
+        }
+        return coerceVariableValue(value, nonNull.OfType)
+    }
+    if value == nil {
+        return nil, nil
+    }
+
+    switch t := targetType.(type) {
+    case *List:
+        listValue, ok := value.([]interface{})
+        if !ok {
+            item, err := coerceVariableValue(value, t.Payload)
+            if err != nil {
+                return nil, err
+            }
+            return []interface{}{item}, nil
+        }
+        coercedList := make([]interface{}, 0, len(listValue))
+        for i, itemValue := range listValue {
+            item, err := coerceVariableValue(itemValue, t.Payload)
+            if err != nil {
+                return nil, withInputPath(err, "["+strconv.Itoa(i)+"]")
+            }
+            coercedList = append(coercedList, item)
+        }
+        return coercedList, nil
+    case *InputObject:
+        objectValue, ok := value.(map[string]interface{})
+        if !ok {
+            err := "coerceVariableValue(): input object \""+t.Name+"\" expects an object value."
+            return nil, errors.New(err)
+        }
+        coercedObject := make(map[string]interface{}, len(t.Fields))
+        for fieldName, fieldValue := range objectValue {
+            inputObjectField, ok := t.Fields[fieldName]
+            if !ok {
+                err := "coerceVariableValue(): field \""+fieldName+"\" is not defined by input object \""+t.Name+"\"."
+                return nil, errors.New(err)
+            }
+            coercedFieldValue, err := coerceVariableValue(fieldValue, inputObjectField.Type)
+            if err != nil {
+                return nil, withInputPath(err, "."+fieldName)
+            }
+            coercedObject[fieldName] = coercedFieldValue
+        }
+        if err := fillInputObjectDefaultValues(t, coercedObject); err != nil {
+            return nil, err
+        }
+        return coercedObject, nil
+    case *Enum:
+        enumName, _ := value.(string)
+        enumValue, ok := t.ParseValue(enumName)
+        if !ok {
+            err := fmt.Sprintf("coerceVariableValue(): value \"%v\" does not exist in \"%s\" enum.", value, t.Name)
+            return nil, errors.New(err)
+        }
+        return enumValue, nil
+    case *Scalar:
+        if t.ParseValue != nil {
+            return t.ParseValue(value)
+        }
+        return value, nil
+    }
+    err := "coerceVariableValue(): type \""+targetType.GetName()+"\" is not an input type."
+    return nil, errors.New(err)
+}
+
+// fill missing fields of input object by DefaultValue, NonNull fields are required
+func fillInputObjectDefaultValues(inputObject *InputObject, coercedObject map[string]interface{}) error {
+    for fieldName, inputObjectField := range inputObject.Fields {
+        if _, ok := coercedObject[fieldName]; ok {
+            continue
+        }
+        if inputObjectField.DefaultValue == nil {
+            if _, ok := inputObjectField.Type.(*NonNull); ok {
+                err := "fillInputObjectDefaultValues(): field \""+inputObject.Name+"."+fieldName+"\" of required type \""+inputObjectField.Type.GetName()+"\" was not provided."
+                return errors.New(err)
+            }
+            continue
+        }
+        defaultValue, err := coerceVariableValue(inputObjectField.DefaultValue, inputObjectField.Type)
+        if err != nil {
+            return err
+        }
+        coercedObject[fieldName] = defaultValue
+    }
+    return nil
+}
+
+/**
+ * CoerceVariableValues
+ * coerce request.Variables by VariableDefinitions of operation, the variable type is built from
+ * frontend type tree (NamedType, ListType, NonNullType). Variable not provided is filled by
+ * DefaultValue, or omitted if it does not have DefaultValue.
+ */
+func getQueryVariablesMap(request Request, variableDefinitions []*frontend.VariableDefinition) (map[string]interface{}, error) {
+    queryVariablesMap := make(map[string]interface{}, len(variableDefinitions))
+    
+    for _, variableDefinition := range variableDefinitions {
+        variableName := variableDefinition.Variable.Value
+        variableType, err := getVariableType(&request.Schema, variableDefinition.Type)
+        if err != nil {
+            return nil, newLocatedError(errors.New("getQueryVariablesMap(): variable \"$"+variableName+"\" "+err.Error()), variableDefinition.Location)
+        }
+        inputValue, provided := request.Variables[variableName]
+        // not provided, use DefaultValue
+        if !provided {
+            if variableDefinition.DefaultValue != nil {
+                if queryVariablesMap[variableName], err = coerceLiteralValue(&GlobalVariables{}, variableDefinition.DefaultValue, variableType); err != nil {
+                    return nil, newLocatedError(errors.New("getQueryVariablesMap(): variable \"$"+variableName+"\" has invalid default value; "+err.Error()), variableDefinition.Location)
+                }
+                continue
+            }
+            if _, ok := variableType.(*NonNull); ok {
+                err := "getQueryVariablesMap(): variable \"$"+variableName+"\" of required type \""+variableType.GetName()+"\" was not provided."
+                return nil, newLocatedError(errors.New(err), variableDefinition.Location)
+            }
+            continue
+        }
+        coercedValue, err := coerceVariableValue(inputValue, variableType)
+        if err != nil {
+            if inputPath := getInputPath(err); inputPath != "" {
+                return nil, newLocatedError(errors.New("getQueryVariablesMap(): variable \"$"+variableName+"\" got invalid value at \""+variableName+inputPath+"\"; "+err.Error()), variableDefinition.Location)
+            }
+            return nil, newLocatedError(errors.New("getQueryVariablesMap(): variable \"$"+variableName+"\" got invalid value; "+err.Error()), variableDefinition.Location)
+        }
+        queryVariablesMap[variableName] = coercedValue
+    }
+    return queryVariablesMap, nil
+}
+
+// build schema input type from VariableDefinition type, e.g. "[Int!]!" returns NonNull(List(NonNull(Int)))
+func getVariableType(schema *Schema, variableType frontend.Type) (Type, error) {
+    switch t := variableType.(type) {
+    case *frontend.NamedType:
+        namedType := schema.GetType(t.Value)
+        if namedType == nil {
+            return nil, errors.New("type \""+t.Value+"\" is not defined in schema.")
+        }
+        if !isInputType(namedType) {
+            return nil, errors.New("type \""+t.Value+"\" is not an input type.")
+        }
+        return namedType, nil
+    case frontend.ListType:
+        if len(t.Type) == 0 {
+            return nil, errors.New("list type does not have item type.")
+        }
+        payload, err := getVariableType(schema, t.Type[0])
+        if err != nil {
+            return nil, err
+        }
+        return NewList(payload), nil
+    case frontend.NonNullType:
+        ofType, err := getVariableType(schema, t.Type)
+        if err != nil {
+            return nil, err
+        }
+        return NewNonNull(ofType), nil
+    }
+    return nil, errors.New("type is illegal.")
+}
+
+/**
+ * CoerceArgumentValues
+ * coerce Field or Directive arguments by schema defined Arguments. Argument referenced variable which
+ * is not provided is omitted, omitted arguments are filled by Argument.DefaultValue, NonNull arguments
+ * without DefaultValue must be provided.
+ */
+func coerceArgumentValues(g *GlobalVariables, arguments []*frontend.Argument, targetArguments *Arguments) (map[string]interface{}, error) {
+    inputArguments := make(map[string]interface{}, len(arguments))
+    for _, argument := range arguments {
+        inputArguments[argument.Name.Value] = argument.Value
+    }
+    if _, err := checkIfInputArgumentsAvaliable(inputArguments, targetArguments); err != nil {
+        return nil, err
+    }
+
+    coercedArguments := make(map[string]interface{}, len(arguments))
+    for _, argument := range arguments {
+        argumentName   := argument.Name.Value
+        targetArgument := (*targetArguments)[argumentName]
+        if isMissingVariable(g, argument.Value) {
+            continue
+        }
+        coercedValue, err := coerceLiteralValue(g, argument.Value, targetArgument.Type)
+        if err != nil {
+            return nil, newLocatedError(err, argument.Location)
+        }
+        coercedArguments[argumentName] = coercedValue
+    }
+    if err := fillArgumentDefaultValues(coercedArguments, targetArguments); err != nil {
+        return nil, err
+    }
+    if err := checkRequiredArguments(coercedArguments, targetArguments); err != nil {
+        return nil, err
+    }
+    return coercedArguments, nil
+}
+
+// fill omitted arguments by DefaultValue, DefaultValue is coerced as variable value
+func fillArgumentDefaultValues(coercedArguments map[string]interface{}, targetArguments *Arguments) error {
+    if targetArguments == nil {
+        return nil
+    }
+    for argumentName, targetArgument := range *targetArguments {
+        if _, ok := coercedArguments[argumentName]; ok || targetArgument.DefaultValue == nil {
+            continue
+        }
+        defaultValue, err := coerceVariableValue(targetArgument.DefaultValue, targetArgument.Type)
+        if err != nil {
+            return err
+        }
+        coercedArguments[argumentName] = defaultValue
+    }
+    return nil
+}
+
+// decode coerced input value (e.g. InputObject argument) into user Go struct by json tag
+func DecodeInputValue(inputValue interface{}, target interface{}) error {
+    if reflect.ValueOf(target).Kind() != reflect.Ptr {
+        return errors.New("DecodeInputValue(): target must be a pointer.")
+    }
+    encoded, err := json.Marshal(inputValue)
+    if err != nil {
+        return err
+    }
+    return json.Unmarshal(encoded, target)
+}
@@ -0,0 +1,322 @@
+// values_test.go
+
+package backend
+
+import (
+    "fmt"
+    "testing"
+)
+
+// fields of values test schema resolve to the printed coerced arguments, e.g. "map[id:1]"
+func resolveArguments(p ResolveParams) (interface{}, error) {
+    return fmt.Sprint(p.Arguments), nil
+}
+
+func newValuesTestSchema(t *testing.T, fields ObjectFields) Schema {
+    t.Helper()
+    query, err := NewObject(ObjectTemplate{Name: "Query", Fields: fields})
+    if err != nil {
+        t.Fatalf("NewObject() error: %v", err)
+    }
+    return newExecuteTestSchema(t, SchemaTemplate{Query: query})
+}
+
+func newUserInput(t *testing.T) *InputObject {
+    t.Helper()
+    addressInput, _ := NewInputObject(InputObjectTemplate{
+        Name: "AddressInput",
+        Fields: InputObjectFields{
+            "city": &InputObjectField{Name: "city", Type: NewNonNull(String)},
+        },
+    })
+    userInput, _ := NewInputObject(InputObjectTemplate{
+        Name: "UserInput",
+        Fields: InputObjectFields{
+            "name":    &InputObjectField{Name: "name", Type: NewNonNull(String)},
+            "age":     &InputObjectField{Name: "age", Type: Int, DefaultValue: 18},
+            "address": &InputObjectField{Name: "address", Type: addressInput},
+            "tags":    &InputObjectField{Name: "tags", Type: NewList(String)},
+        },
+    })
+    return userInput
+}
+
+func TestExecuteInputObject(t *testing.T) {
+    schema := newValuesTestSchema(t, ObjectFields{
+        "user": &ObjectField{
+            Name: "user",
+            Type: String,
+            Arguments: &Arguments{
+                "input": &Argument{Name: "input", Type: newUserInput(t)},
+            },
+            ResolveFunction: resolveArguments,
+        },
+    })
+    runExecuteTests(t, schema, []executeTest{
+        {name: "literal", query: `{ user(input: {name: "a", age: 3, address: {city: "x"}, tags: ["t"]}) }`,
+            result: `{"data":{"user":"map[input:map[address:map[city:x] age:3 name:a tags:[t]]]"}}`},
+        {name: "literal default value", query: `{ user(input: {name: "a"}) }`,
+            result: `{"data":{"user":"map[input:map[age:18 name:a]]"}}`},
+        {name: "literal null field", query: `{ user(input: {name: "a", age: null, address: null}) }`,
+            result: `{"data":{"user":"map[input:map[address:\u003cnil\u003e age:\u003cnil\u003e name:a]]"}}`},
+        {name: "literal unknown field", query: `{ user(input: {name: "a", nick: "b"}) }`,
+            result: `{"data":null,"errors":[{"message":"Field \"nick\" is not defined by type \"UserInput\".","locations":[{"line":1,"column":27}]}]}`},
+        {name: "literal missing required field", query: `{ user(input: {age: 1}) }`,
+            result: `{"data":null,"errors":[{"message":"Field \"UserInput.name\" of required type \"String!\" was not provided.","locations":[{"line":1,"column":15}]}]}`},
+        {name: "literal nested variable", query: `query Q($city: String!) { user(input: {name: "a", address: {city: $city}}) }`, variables: `{"city": "y"}`,
+            result: `{"data":{"user":"map[input:map[address:map[city:y] age:18 name:a]]"}}`},
+        {name: "variable", query: `query Q($u: UserInput) { user(input: $u) }`, variables: `{"u": {"name": "a", "address": {"city": "x"}, "tags": ["t"]}}`,
+            result: `{"data":{"user":"map[input:map[address:map[city:x] age:18 name:a tags:[t]]]"}}`},
+        {name: "variable unknown field", query: `query Q($u: UserInput) { user(input: $u) }`, variables: `{"u": {"name": "a", "nick": "b"}}`,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$u\" got invalid value; coerceVariableValue(): field \"nick\" is not defined by input object \"UserInput\".","locations":[{"line":1,"column":9}]}]}`},
+        {name: "variable missing required field", query: `query Q($u: UserInput) { user(input: $u) }`, variables: `{"u": {"age": 1}}`,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$u\" got invalid value; fillInputObjectDefaultValues(): field \"UserInput.name\" of required type \"String!\" was not provided.","locations":[{"line":1,"column":9}]}]}`},
+        {name: "variable nested null", query: `query Q($u: UserInput) { user(input: $u) }`, variables: `{"u": {"name": "a", "address": {"city": null}}}`,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$u\" got invalid value at \"u.address.city\"; coerceVariableValue(): expected non-nullable type \"String!\" not to be null.","locations":[{"line":1,"column":9}]}]}`},
+        {name: "variable not an object", query: `query Q($u: UserInput) { user(input: $u) }`, variables: `{"u": "a"}`,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$u\" got invalid value; coerceVariableValue(): input object \"UserInput\" expects an object value.","locations":[{"line":1,"column":9}]}]}`},
+    })
+}
+
+func TestDecodeInputValue(t *testing.T) {
+    type address struct {
+        City string `json:"city"`
+    }
+    var user struct {
+        Name    string   `json:"name"`
+        Age     int      `json:"age"`
+        Address *address `json:"address"`
+        Tags    []string `json:"tags"`
+    }
+    inputValue := map[string]interface{}{"name": "a", "age": 18, "address": map[string]interface{}{"city": "x"}, "tags": []interface{}{"t"}}
+    if err := DecodeInputValue(inputValue, &user); err != nil {
+        t.Fatalf("DecodeInputValue() error: %v", err)
+    }
+    if user.Name != "a" || user.Age != 18 || user.Address == nil || user.Address.City != "x" || len(user.Tags) != 1 || user.Tags[0] != "t" {
+        t.Errorf("DecodeInputValue() = %+v", user)
+    }
+    if err := DecodeInputValue(inputValue, user); err == nil || err.Error() != "DecodeInputValue(): target must be a pointer." {
+        t.Errorf("DecodeInputValue() non-pointer error = %v", err)
+    }
+}
+
+func TestExecuteArgumentCoercion(t *testing.T) {
+    color, _ := NewEnum(EnumTemplate{
+        Name: "Color",
+        Values: EnumValues{
+            "RED":  &EnumValue{Value: 1},
+            "BLUE": &EnumValue{Value: 2},
+        },
+    })
+    schema := newValuesTestSchema(t, ObjectFields{
+        "scalars": &ObjectField{
+            Name: "scalars",
+            Type: String,
+            Arguments: &Arguments{
+                "i":  &Argument{Name: "i", Type: Int},
+                "f":  &Argument{Name: "f", Type: Float},
+                "s":  &Argument{Name: "s", Type: String},
+                "b":  &Argument{Name: "b", Type: Bool},
+                "id": &Argument{Name: "id", Type: ID},
+            },
+            ResolveFunction: func(p ResolveParams) (interface{}, error) {
+                return fmt.Sprintf("%#v", p.Arguments["id"]) + " " + fmt.Sprintf("%T", p.Arguments["f"]) + " " + fmt.Sprint(p.Arguments), nil
+            },
+        },
+        "color": &ObjectField{
+            Name: "color",
+            Type: String,
+            Arguments: &Arguments{
+                "color": &Argument{Name: "color", Type: color},
+            },
+            ResolveFunction: resolveArguments,
+        },
+        "list": &ObjectField{
+            Name: "list",
+            Type: String,
+            Arguments: &Arguments{
+                "ids":    &Argument{Name: "ids", Type: NewList(NewNonNull(Int))},
+                "matrix": &Argument{Name: "matrix", Type: NewList(NewList(Int))},
+            },
+            ResolveFunction: resolveArguments,
+        },
+        "required": &ObjectField{
+            Name: "required",
+            Type: String,
+            Arguments: &Arguments{
+                "x": &Argument{Name: "x", Type: NewNonNull(Int)},
+            },
+            ResolveFunction: resolveArguments,
+        },
+    })
+    runExecuteTests(t, schema, []executeTest{
+        {name: "scalars", query: `{ scalars(i: 1, f: 2.5, s: "s", b: true, id: "x") }`,
+            result: `{"data":{"scalars":"\"x\" float64 map[b:true f:2.5 i:1 id:x s:s]"}}`},
+        {name: "Int accepted for Float and ID", query: `{ scalars(f: 2, id: 7) }`,
+            result: `{"data":{"scalars":"\"7\" float64 map[f:2 id:7]"}}`},
+        {name: "null", query: `{ scalars(i: null) }`,
+            result: `{"data":{"scalars":"\u003cnil\u003e \u003cnil\u003e map[i:\u003cnil\u003e]"}}`},
+        {name: "enum", query: `{ color(color: BLUE) }`,
+            result: `{"data":{"color":"map[color:2]"}}`},
+        {name: "list", query: `{ list(ids: [1, 2], matrix: [[1], [2, null]]) }`,
+            result: `{"data":{"list":"map[ids:[1 2] matrix:[[1] [2 \u003cnil\u003e]]]"}}`},
+        {name: "single item wrapped in list", query: `{ list(ids: 1, matrix: 2) }`,
+            result: `{"data":{"list":"map[ids:[1] matrix:[[2]]]"}}`},
+        {name: "String for Int", query: `{ scalars(i: "1") }`,
+            result: `{"data":null,"errors":[{"message":"Expected value of type \"Int\", found \"1\".","locations":[{"line":1,"column":14}]}]}`},
+        {name: "Float for Int", query: `{ scalars(i: 1.5) }`,
+            result: `{"data":null,"errors":[{"message":"Expected value of type \"Int\", found 1.5.","locations":[{"line":1,"column":14}]}]}`},
+        {name: "unknown enum value", query: `{ color(color: GREEN) }`,
+            result: `{"data":null,"errors":[{"message":"Value \"GREEN\" does not exist in \"Color\" enum.","locations":[{"line":1,"column":16}]}]}`},
+        {name: "String for enum", query: `{ color(color: "RED") }`,
+            result: `{"data":null,"errors":[{"message":"Enum \"Color\" cannot represent non-enum value: \"RED\".","locations":[{"line":1,"column":16}]}]}`},
+        {name: "null list item", query: `{ list(ids: [1, null]) }`,
+            result: `{"data":null,"errors":[{"message":"Expected value of type \"Int!\", found null.","locations":[{"line":1,"column":17}]}]}`},
+        {name: "unknown argument", query: `{ scalars(x: 1) }`,
+            result: `{"data":null,"errors":[{"message":"Unknown argument \"x\" on field \"scalars\" of type \"Query\".","locations":[{"line":1,"column":11}]}]}`},
+        {name: "missing required argument", query: `{ required }`,
+            result: `{"data":null,"errors":[{"message":"Field \"required\" argument \"x\" of type \"Int!\" is required, but it was not provided.","locations":[{"line":1,"column":3}]}]}`},
+        {name: "null required argument", query: `{ required(x: null) }`,
+            result: `{"data":null,"errors":[{"message":"Expected value of type \"Int!\", found null.","locations":[{"line":1,"column":15}]}]}`},
+        {name: "variable of wrong type", query: `query Q($s: String) { scalars(i: $s) }`, variables: `{"s": "1"}`,
+            result: `{"data":null,"errors":[{"message":"Variable \"$s\" of type \"String\" used in position expecting type \"Int\".","locations":[{"line":1,"column":34}]}]}`},
+        {name: "variable value", query: `query Q($i: Int, $c: Color) { scalars(i: $i) color(color: $c) }`, variables: `{"i": 3, "c": "RED"}`,
+            result: `{"data":{"color":"map[color:1]","scalars":"\u003cnil\u003e \u003cnil\u003e map[i:3]"}}`},
+        {name: "invalid variable value", query: `query Q($i: Int) { scalars(i: $i) }`, variables: `{"i": 1.5}`,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$i\" got invalid value; Int.ParseValue(): Int cannot represent non-integer value: 1.5.","locations":[{"line":1,"column":9}]}]}`},
+        {name: "invalid enum variable value", query: `query Q($c: Color) { color(color: $c) }`, variables: `{"c": "GREEN"}`,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$c\" got invalid value; coerceVariableValue(): value \"GREEN\" does not exist in \"Color\" enum.","locations":[{"line":1,"column":9}]}]}`},
+    })
+}
+
+func TestExecuteArgumentDefaultValue(t *testing.T) {
+    order, _ := NewEnum(EnumTemplate{
+        Name: "Order",
+        Values: EnumValues{
+            "ASC":  &EnumValue{Value: "asc"},
+            "DESC": &EnumValue{Value: "desc"},
+        },
+    })
+    schema := newValuesTestSchema(t, ObjectFields{
+        "users": &ObjectField{
+            Name: "users",
+            Type: String,
+            Arguments: &Arguments{
+                "first": &Argument{Name: "first", Type: NewNonNull(Int), DefaultValue: 10},
+                "order": &Argument{Name: "order", Type: order, DefaultValue: "ASC"},
+                "names": &Argument{Name: "names", Type: NewList(String), DefaultValue: []interface{}{"a"}},
+                "user":  &Argument{Name: "user", Type: newUserInput(t), DefaultValue: map[string]interface{}{"name": "b"}},
+                "after": &Argument{Name: "after", Type: String},
+            },
+            ResolveFunction: resolveArguments,
+        },
+        "invalid": &ObjectField{
+            Name: "invalid",
+            Type: String,
+            Arguments: &Arguments{
+                "x": &Argument{Name: "x", Type: Int, DefaultValue: "x"},
+            },
+            ResolveFunction: resolveArguments,
+        },
+    })
+    runExecuteTests(t, schema, []executeTest{
+        {name: "omitted arguments", query: `{ users }`,
+            result: `{"data":{"users":"map[first:10 names:[a] order:asc user:map[age:18 name:b]]"}}`},
+        {name: "provided arguments", query: `{ users(first: 1, order: DESC, names: [], user: {name: "c"}, after: "x") }`,
+            result: `{"data":{"users":"map[after:x first:1 names:[] order:desc user:map[age:18 name:c]]"}}`},
+        {name: "explicit null", query: `{ users(order: null, names: null) }`,
+            result: `{"data":{"users":"map[first:10 names:\u003cnil\u003e order:\u003cnil\u003e user:map[age:18 name:b]]"}}`},
+        {name: "variable not provided", query: `query Q($first: Int, $order: Order) { users(first: $first, order: $order) }`,
+            result: `{"data":{"users":"map[first:10 names:[a] order:asc user:map[age:18 name:b]]"}}`},
+        {name: "variable provided", query: `query Q($first: Int, $order: Order) { users(first: $first, order: $order) }`, variables: `{"first": 2, "order": "DESC"}`,
+            result: `{"data":{"users":"map[first:2 names:[a] order:desc user:map[age:18 name:b]]"}}`},
+        {name: "invalid default value", query: `{ invalid }`,
+            result: `{"data":{"invalid":null},"errors":[{"message":"Int.ParseValue(): Int cannot represent non-integer value: x.","locations":[{"line":1,"column":3}],"path":["invalid"]}]}`},
+    })
+
+    printed := PrintType(schema.Query)
+    want := "type Query {\n  invalid(x: Int = \"x\"): String\n  users(after: String, first: Int! = 10, names: [String] = [\"a\"], order: Order = ASC, user: UserInput = {name: \"b\"}): String\n}"
+    if printed != want {
+        t.Errorf("PrintType() =\n%s\nwant:\n%s", printed, want)
+    }
+}
+
+func TestExecuteVariableCoercion(t *testing.T) {
+    schema := newValuesTestSchema(t, ObjectFields{
+        "variables": &ObjectField{
+            Name: "variables",
+            Type: String,
+            Arguments: &Arguments{
+                "ids":    &Argument{Name: "ids", Type: NewList(NewNonNull(Int))},
+                "matrix": &Argument{Name: "matrix", Type: NewList(NewList(Int))},
+                "users":  &Argument{Name: "users", Type: NewList(NewNonNull(newUserInput(t)))},
+                "n":      &Argument{Name: "n", Type: Int},
+            },
+            ResolveFunction: resolveArguments,
+        },
+    })
+    idsQuery   := `query Q($ids: [Int!]!) { variables(ids: $ids) }`
+    usersQuery := `query Q($users: [UserInput!]) { variables(users: $users) }`
+    runExecuteTests(t, schema, []executeTest{
+        {name: "list", query: idsQuery, variables: `{"ids": [1, 2.0]}`,
+            result: `{"data":{"variables":"map[ids:[1 2]]"}}`},
+        {name: "single value wrapped in list", query: idsQuery, variables: `{"ids": 1}`,
+            result: `{"data":{"variables":"map[ids:[1]]"}}`},
+        {name: "null list item", query: idsQuery, variables: `{"ids": [1, null]}`,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$ids\" got invalid value at \"ids[1]\"; coerceVariableValue(): expected non-nullable type \"Int!\" not to be null.","locations":[{"line":1,"column":9}]}]}`},
+        {name: "invalid list item", query: idsQuery, variables: `{"ids": [1, 2, "x"]}`,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$ids\" got invalid value at \"ids[2]\"; Int.ParseValue(): Int cannot represent non-integer value: x.","locations":[{"line":1,"column":9}]}]}`},
+        {name: "null required variable", query: idsQuery, variables: `{"ids": null}`,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$ids\" got invalid value; coerceVariableValue(): expected non-nullable type \"[Int!]!\" not to be null.","locations":[{"line":1,"column":9}]}]}`},
+        {name: "required variable not provided", query: idsQuery,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$ids\" of required type \"[Int!]!\" was not provided.","locations":[{"line":1,"column":9}]}]}`},
+        {name: "nested list", query: `query Q($m: [[Int]]) { variables(matrix: $m) }`, variables: `{"m": [[1], [2, "x"]]}`,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$m\" got invalid value at \"m[1][1]\"; Int.ParseValue(): Int cannot represent non-integer value: x.","locations":[{"line":1,"column":9}]}]}`},
+        {name: "input object list", query: usersQuery, variables: `{"users": [{"name": "a", "tags": ["t"]}, {"name": "b", "age": 3}]}`,
+            result: `{"data":{"variables":"map[users:[map[age:18 name:a tags:[t]] map[age:3 name:b]]]"}}`},
+        {name: "input object field list item", query: usersQuery, variables: `{"users": [{"name": "a", "tags": ["t", 1]}]}`,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$users\" got invalid value at \"users[0].tags[1]\"; String.ParseValue(): String cannot represent a non string value: 1.","locations":[{"line":1,"column":9}]}]}`},
+        {name: "nested input object field", query: usersQuery, variables: `{"users": [{"name": "a"}, {"name": "b", "address": {"city": 1}}]}`,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$users\" got invalid value at \"users[1].address.city\"; String.ParseValue(): String cannot represent a non string value: 1.","locations":[{"line":1,"column":9}]}]}`},
+        {name: "nested input object missing field", query: usersQuery, variables: `{"users": [{"name": "a"}, {"name": "b", "address": {}}]}`,
+            result: `{"data":null,"errors":[{"message":"getQueryVariablesMap(): variable \"$users\" got invalid value at \"users[1].address\"; fillInputObjectDefaultValues(): field \"AddressInput.city\" of required type \"String!\" was not provided.","locations":[{"line":1,"column":9}]}]}`},
+        {name: "default value", query: `query Q($n: Int = 5) { variables(n: $n) }`,
+            result: `{"data":{"variables":"map[n:5]"}}`},
+        {name: "default value overridden by null", query: `query Q($n: Int = 5) { variables(n: $n) }`, variables: `{"n": null}`,
+            result: `{"data":{"variables":"map[n:\u003cnil\u003e]"}}`},
+        {name: "unknown variable type", query: `query Q($n: Number) { variables(n: $n) }`,
+            result: `{"data":null,"errors":[{"message":"Unknown type \"Number\".","locations":[{"line":1,"column":9}]}]}`},
+    })
+}
+
+func TestExecuteVariableLookup(t *testing.T) {
+    schema := newValuesTestSchema(t, ObjectFields{
+        "pair": &ObjectField{
+            Name: "pair",
+            Type: String,
+            Arguments: &Arguments{
+                "a":    &Argument{Name: "a", Type: Int},
+                "b":    &Argument{Name: "b", Type: Int},
+                "ids":  &Argument{Name: "ids", Type: NewList(Int)},
+                "user": &Argument{Name: "user", Type: newUserInput(t)},
+            },
+            ResolveFunction: resolveArguments,
+        },
+    })
+    runExecuteTests(t, schema, []executeTest{
+        {name: "variable name differs from argument name", query: `query Q($first: Int) { pair(a: $first) }`, variables: `{"first": 1}`,
+            result: `{"data":{"pair":"map[a:1]"}}`},
+        {name: "variables swapped", query: `query Q($a: Int, $b: Int) { pair(a: $b, b: $a) }`, variables: `{"a": 1, "b": 2}`,
+            result: `{"data":{"pair":"map[a:2 b:1]"}}`},
+        {name: "variable in list", query: `query Q($x: Int) { pair(ids: [1, $x]) }`, variables: `{"x": 2}`,
+            result: `{"data":{"pair":"map[ids:[1 2]]"}}`},
+        {name: "variable not provided in list", query: `query Q($x: Int) { pair(ids: [1, $x]) }`,
+            result: `{"data":{"pair":"map[ids:[1 \u003cnil\u003e]]"}}`},
+        {name: "variable in object", query: `query Q($n: String!, $t: String, $age: Int) { pair(user: {name: $n, tags: [$t], age: $age}) }`, variables: `{"n": "a", "t": "t", "age": 3}`,
+            result: `{"data":{"pair":"map[user:map[age:3 name:a tags:[t]]]"}}`},
+        {name: "variable not provided in object uses default value", query: `query Q($n: String!, $age: Int) { pair(user: {name: $n, age: $age}) }`, variables: `{"n": "a"}`,
+            result: `{"data":{"pair":"map[user:map[age:18 name:a]]"}}`},
+        {name: "variable in directive", query: `query Q($hide: Boolean!) { a: pair @skip(if: $hide) b: pair @include(if: $hide) }`, variables: `{"hide": true}`,
+            result: `{"data":{"b":"map[]"}}`},
+    })
+}
@@ -3,10 +3,8 @@
 package main
 
 import (
-    "flag"
     "fmt"
     "os"
-    "io/ioutil"
     "fast-graphql/src/frontend"
     "fast-graphql/src/backend"
     "github.com/davecgh/go-spew/spew"
@@ -20,13 +18,18 @@ Avaliable options are:
     -o name  output to file 'name' (default is "fastgraphqlc.out")
 `
 
+type User struct {
+    Id    string `json:"id"`
+    Name  string `json:"name"`
+    Email string `json:"email"`
+}
+
 func main() {
     // pre config
     spewo := spew.ConfigState{
         Indent: "    ", 
         DisablePointerAddresses: true,
-        DisableCapacities: true,
-        DisableVariableType: true}
+        DisableCapacities: true}
 
     // query string
     queryString := `
@@ -39,25 +42,60 @@ func main() {
         }
     `
 
-    // query config
-    fields := backend.Fields{
-        "user": &backend.Field{
-            Type: "string",
-            ResolveFunc: func(i interface{}) (interface{}, error) {
-                return {Id: "a2xrbHNka2ljdmlpaWFqbg==", Name: "Apple", Email: "apple@email.com"}, nil
-            }
-        }
-    }
+    // schema config
+    userType, _ := backend.NewObject(backend.ObjectTemplate{
+        Name: "User",
+        Fields: backend.ObjectFields{
+            "id": &backend.ObjectField{
+                Name: "id",
+                Type: backend.String,
+            },
+            "name": &backend.ObjectField{
+                Name: "name",
+                Type: backend.String,
+            },
+            "email": &backend.ObjectField{
+                Name: "email",
+                Type: backend.String,
+            },
+        },
+    })
+    queryObject, _ := backend.NewObject(backend.ObjectTemplate{
+        Name: "Query",
+        Fields: backend.ObjectFields{
+            "user": &backend.ObjectField{
+                Name: "user",
+                Type: userType,
+                ResolveFunction: func(p backend.ResolveParams) (interface{}, error) {
+                    return User{Id: "a2xrbHNka2ljdmlpaWFqbg==", Name: "Apple", Email: "apple@email.com"}, nil
+                },
+            },
+        },
+    })
+    schema, _ := backend.NewSchema(backend.SchemaTemplate{
+        Query: queryObject,
+    })
 
     // compile
     fmt.Printf("Now parsing: %s\n", queryString)
-    ast := frontend.Compile(queryString)
+    ast, err := frontend.Compile(queryString)
+    if err != nil {
+        if syntaxError, ok := err.(*frontend.SyntaxError); ok {
+            fmt.Printf("%v\n%s\n", syntaxError, syntaxError.Excerpt)
+            return
+        }
+        fmt.Println(err)
+        return
+    }
 
     // dump ast
     spewo.Dump(ast)
 
     // execute
-    r := backend.Execute(ast, fields)
+    r := backend.Execute(backend.Request{
+        Schema: schema,
+        Query:  queryString,
+    })
 
     spewo.Dump(r)
     return
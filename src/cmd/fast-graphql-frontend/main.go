@@ -48,7 +48,13 @@ func main() {
 
     // compile
     fmt.Printf("Now parsing file: %s\n", fileName)
-    frontend.Compile(fileContent)
+    if _, err := frontend.Compile(fileContent); err != nil {
+        if syntaxError, ok := err.(*frontend.SyntaxError); ok {
+            fmt.Printf("%v\n%s\n", syntaxError, syntaxError.Excerpt)
+            return
+        }
+        fmt.Println(err)
+    }
     return
 }
 
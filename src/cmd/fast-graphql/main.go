@@ -52,7 +52,14 @@ func main() {
     fmt.Println("- [PARSING PHRASE] --------------------------------------------------------------------\n\n")
     fmt.Printf("Now parsing file: %s\n", fileName)
 
-    document := frontend.Compile(fileContent)
+    if _, err := frontend.Compile(fileContent); err != nil {
+        if syntaxError, ok := err.(*frontend.SyntaxError); ok {
+            fmt.Printf("%v\n%s\n", syntaxError, syntaxError.Excerpt)
+            return
+        }
+        fmt.Println(err)
+        return
+    }
 
     fmt.Println("\n\n")
     fmt.Println("- [EXECUTE PHRASE] --------------------------------------------------------------------\n\n")
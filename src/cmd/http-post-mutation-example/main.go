@@ -69,6 +69,21 @@ var users = []User{
     },
 }
 
+var genderEnum, _ = backend.NewEnum(
+    backend.EnumTemplate{
+        Name: "Gender",
+        Description: "User gender",
+        Values: backend.EnumValues{
+            "MALE": &backend.EnumValue{
+                Value: Gender_Male,
+            },
+            "FEMALE": &backend.EnumValue{
+                Value: Gender_Female,
+            },
+        },
+    },
+)
+
 var userType, _ = backend.NewObject(
     backend.ObjectTemplate{
         Name: "User",
@@ -95,7 +110,7 @@ var userType, _ = backend.NewObject(
             },
             "gender": &backend.ObjectField{
                 Name: "gender",
-                Type: backend.String,
+                Type: genderEnum,
             },
         },
     },
@@ -130,7 +145,7 @@ var queryObject, _ = backend.NewObject(
                     },
                     "gender": &backend.Argument{
                         Name: "gender",
-                        Type: backend.String,
+                        Type: genderEnum,
                     },
                 },
                 ResolveFunction: func(p backend.ResolveParams) (interface{}, error) {
@@ -195,7 +210,7 @@ var queryObject, _ = backend.NewObject(
                             }
                         }
                     }
-                    return nil, errors.New("ResolveFunction(): target data not found.")
+                    return nil, backend.NewExtendedError("ResolveFunction(): target data not found.", map[string]interface{}{"code": "NOT_FOUND"})
                 },
             },
             // Field List
@@ -213,6 +228,70 @@ var queryObject, _ = backend.NewObject(
 
 
 
+// user input for create and update mutation, nil field means not provided
+type UserInput struct {
+    Id      *int     `json:"id"`
+    Name    *string  `json:"name"`
+    Email   *string  `json:"email"`
+    Married *bool    `json:"married"`
+    Height  *float64 `json:"height"`
+    Gender  *string  `json:"gender"`
+}
+
+var userInputType, _ = backend.NewInputObject(
+    backend.InputObjectTemplate{
+        Name: "UserInput",
+        Description: "user info for create and update",
+        Fields: backend.InputObjectFields{
+            "id": &backend.InputObjectField{
+                Name: "id",
+                Type: backend.Int,
+                Description: "target user id, for update only",
+            },
+            "name": &backend.InputObjectField{
+                Name: "name",
+                Type: backend.String,
+            },
+            "email": &backend.InputObjectField{
+                Name: "email",
+                Type: backend.String,
+            },
+            // no DefaultValue, omitted field keeps the current value on update
+            "married": &backend.InputObjectField{
+                Name: "married",
+                Type: backend.Bool,
+            },
+            "height": &backend.InputObjectField{
+                Name: "height",
+                Type: backend.Float,
+            },
+            "gender": &backend.InputObjectField{
+                Name: "gender",
+                Type: genderEnum,
+            },
+        },
+    },
+)
+
+// apply provided UserInput fields to user
+func (userInput UserInput) applyTo(user *User) {
+    if userInput.Name != nil {
+        user.Name = *userInput.Name
+    }
+    if userInput.Email != nil {
+        user.Email = *userInput.Email
+    }
+    if userInput.Married != nil {
+        user.Married = *userInput.Married
+    }
+    if userInput.Height != nil {
+        user.Height = *userInput.Height
+    }
+    if userInput.Gender != nil {
+        user.Gender = *userInput.Gender
+    }
+}
+
 var mutationObject, _ = backend.NewObject(
     backend.ObjectTemplate{
         Name: "Mutation",
@@ -222,36 +301,20 @@ var mutationObject, _ = backend.NewObject(
                 Type: userType,
                 Description: "create new user",
                 Arguments: &backend.Arguments{
-                    "name": &backend.Argument{
-                        Name: "name",
-                        Type: backend.String,
-                    },
-                    "email": &backend.Argument{
-                        Name: "email",
-                        Type: backend.String,
-                    },
-                    "married": &backend.Argument{
-                        Name: "married",
-                        Type: backend.Bool,
-                    },
-                    "height": &backend.Argument{
-                        Name: "height",
-                        Type: backend.Float,
-                    },
-                    "gender": &backend.Argument{
-                        Name: "gender",
-                        Type: backend.String,
+                    "input": &backend.Argument{
+                        Name: "input",
+                        Type: userInputType,
                     },
                 },
                 ResolveFunction: func(p backend.ResolveParams) (interface{}, error) {
+                    var userInput UserInput
+                    if err := backend.DecodeInputValue(p.Arguments["input"], &userInput); err != nil {
+                        return nil, err
+                    }
                     user := User{
                         Id: rand.Intn(1000),
-                        Name: p.Arguments["name"].(string),
-                        Email: p.Arguments["email"].(string),
-                        Married: p.Arguments["married"].(bool),
-                        Height: p.Arguments["height"].(float64),
-                        Gender: p.Arguments["gender"].(string),
                     }
+                    userInput.applyTo(&user)
                     users = append(users, user)
                     return user, nil
                 },
@@ -261,61 +324,27 @@ var mutationObject, _ = backend.NewObject(
                 Type: userType,
                 Description: "update user info",
                 Arguments: &backend.Arguments{
-                    "id": &backend.Argument{
-                        Name: "id",
-                        Type: backend.Int,
-                    },
-                    "name": &backend.Argument{
-                        Name: "name",
-                        Type: backend.String,
-                    },
-                    "email": &backend.Argument{
-                        Name: "email",
-                        Type: backend.String,
-                    },
-                    "married": &backend.Argument{
-                        Name: "married",
-                        Type: backend.Bool,
-                    },
-                    "height": &backend.Argument{
-                        Name: "height",
-                        Type: backend.Float,
-                    },
-                    "gender": &backend.Argument{
-                        Name: "gender",
-                        Type: backend.String,
+                    "input": &backend.Argument{
+                        Name: "input",
+                        Type: userInputType,
                     },
                 },
                 ResolveFunction: func(p backend.ResolveParams) (interface{}, error) {
-                    id, _              := p.Arguments["id"].(int)
-                    name, nameOk       := p.Arguments["name"].(string)
-                    email, emailOk       := p.Arguments["email"].(string)
-                    married, marriedOk := p.Arguments["married"].(bool)
-                    height, heightOk   := p.Arguments["height"].(float64)
-                    gender, genderOk   := p.Arguments["gender"].(string)
+                    var userInput UserInput
+                    if err := backend.DecodeInputValue(p.Arguments["input"], &userInput); err != nil {
+                        return nil, err
+                    }
+                    if userInput.Id == nil {
+                        return nil, errors.New("ResolveFunction(): UserInput.id is required for update.")
+                    }
                     // find target user and update
-                    target := 0
                     for i, user := range users {
-                        if user.Id == id {
-                            target = i
-                            if nameOk {
-                                users[i].Name = name
-                            }
-                            if emailOk {
-                                users[i].Email = email
-                            }
-                            if marriedOk {
-                                users[i].Married = married
-                            }
-                            if heightOk {
-                                users[i].Height = height
-                            }
-                            if genderOk {
-                                users[i].Gender = gender
-                            }
+                        if user.Id == *userInput.Id {
+                            userInput.applyTo(&users[i])
+                            return users[i], nil
                         }
                     }
-                    return users[target], nil
+                    return nil, backend.NewExtendedError("ResolveFunction(): target user not found.", map[string]interface{}{"code": "NOT_FOUND"})
                 },
             },
             "delete": &backend.ObjectField{
@@ -354,13 +383,14 @@ var schema, _ = backend.NewSchema(
 )
 
 
-func executeQuery(query string, variables map[string]interface{}, schema backend.Schema) *backend.Result {
+func executeQuery(query string, variables map[string]interface{}, operationName string, schema backend.Schema) *backend.Result {
     var result *backend.Result 
     // execute
     result = backend.Execute(backend.Request{
         Schema: schema,
         Query:  query,
         Variables: variables,
+        OperationName: operationName,
     })
     if len(result.Errors) > 0 {
         fmt.Printf("\n\n\n")
@@ -386,9 +416,10 @@ func main() {
         if decodedVariables["variables"] != nil {
             variables = decodedVariables["variables"].(map[string]interface{})
         }
+        operationName, _ := decodedVariables["operationName"].(string)
         
         // execute
-        result    := executeQuery(query, variables, schema)
+        result    := executeQuery(query, variables, operationName, schema)
 
         // return
         w.Header().Set("content-type","text/json")
@@ -3,14 +3,20 @@ package main
 import (
     "encoding/json"
     "fmt"
+    "log"
     "net/http"
     "io/ioutil"
+    "os"
+    "strings"
 
     "fast-graphql/src/backend"
     "github.com/davecgh/go-spew/spew"
 
 )
 
+// log parse, validate and resolve events of every request
+var tracer = backend.NewLogTracer(log.New(os.Stdout, "[graphql] ", log.LstdFlags))
+
 const (
     Gender_Male   = "MALE"
     Gender_Female = "FEMALE"
@@ -68,6 +74,21 @@ var users = []User{
     },
 }
 
+var genderEnum, _ = backend.NewEnum(
+    backend.EnumTemplate{
+        Name: "Gender",
+        Description: "User gender",
+        Values: backend.EnumValues{
+            "MALE": &backend.EnumValue{
+                Value: Gender_Male,
+            },
+            "FEMALE": &backend.EnumValue{
+                Value: Gender_Female,
+            },
+        },
+    },
+)
+
 var userType, _ = backend.NewObject(
     backend.ObjectTemplate{
         Name: "User",
@@ -94,7 +115,7 @@ var userType, _ = backend.NewObject(
             },
             "gender": &backend.ObjectField{
                 Name: "gender",
-                Type: backend.String,
+                Type: genderEnum,
             },
         },
     },
@@ -128,7 +149,7 @@ var queryObject, _ = backend.NewObject(
                     },
                     "gender": &backend.Argument{
                         Name: "gender",
-                        Type: backend.String,
+                        Type: genderEnum,
                     },
                 },
                 ResolveFunction: func(p backend.ResolveParams) (interface{}, error) {
@@ -210,20 +231,41 @@ var queryObject, _ = backend.NewObject(
 )
 
 
+// user defined directive, e.g. { user(id: 1) { name @uppercase } }
+var uppercaseDirective, _ = backend.NewDirective(
+    backend.DirectiveTemplate{
+        Name: "uppercase",
+        Description: "Convert string field value to upper case",
+        Locations: []string{backend.DirectiveLocationField},
+        DirectiveFunction: func(dp backend.DirectiveParams, resolveFunction backend.ResolveFunction) backend.ResolveFunction {
+            return func(p backend.ResolveParams) (interface{}, error) {
+                resolvedData, err := resolveFunction(p)
+                if str, ok := resolvedData.(string); ok {
+                    return strings.ToUpper(str), err
+                }
+                return resolvedData, err
+            }
+        },
+    },
+)
+
 var schema, _ = backend.NewSchema(
     backend.SchemaTemplate{
         Query: queryObject,
+        Directives: []*backend.Directive{uppercaseDirective},
     },
 )
 
 
-func executeQuery(query string, variables map[string]interface{}, schema backend.Schema) *backend.Result {
+func executeQuery(query string, variables map[string]interface{}, operationName string, schema backend.Schema) *backend.Result {
     var result *backend.Result 
     // execute
     result = backend.Execute(backend.Request{
         Schema: schema,
         Query:  query,
         Variables: variables,
+        OperationName: operationName,
+        Tracer: tracer,
     })
     if len(result.Errors) > 0 {
         fmt.Printf("\n\n\n")
@@ -247,9 +289,10 @@ func main() {
         // HTTP Get method 
         query     := decodedVariables["query"].(string)
         variables := decodedVariables["variables"].(map[string]interface{})
+        operationName, _ := decodedVariables["operationName"].(string)
         
         // execute
-        result    := executeQuery(query, variables, schema)
+        result    := executeQuery(query, variables, operationName, schema)
 
         // return
         w.Header().Set("content-type","text/json")
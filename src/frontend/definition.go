@@ -9,8 +9,9 @@ package frontend
  */
 
 type Name struct {
-    LineNum int
-    Value   string
+    LineNum  int
+    Location Location
+    Value    string
 }
 
 
@@ -55,6 +56,7 @@ const OperationDefinitionType = "OperationDefinition"
 
 type OperationDefinition struct {
     LineNum                int
+    Location               Location
     OperationType          int
     OperationTypeName      string
     Name                  *Name
@@ -106,6 +108,7 @@ func (operationDefinition *OperationDefinition) IsSubscription() bool {
  */
 type SelectionSet struct {
     LineNum     int 
+    Location    Location
     Selections  []Selection
 }
 
@@ -128,6 +131,7 @@ var _ Selection = (*InlineFragment)(nil)
  */
 type Field struct {
     LineNum         int
+    Location        Location
     Alias           *Alias
     Name            *Name
     Arguments       []*Argument
@@ -147,6 +151,7 @@ func (field *Field) GetSelectionSet() *SelectionSet {
  */
 type Alias struct {
     LineNum     int 
+    Location    Location
     Name       *Name
 }
 
@@ -159,6 +164,7 @@ type Alias struct {
  */
 type Argument struct {
     LineNum  int
+    Location Location
     Name    *Name
     Value    Value
 }
@@ -178,6 +184,7 @@ const FragmentDefinitionType = "FragmentDefinition"
 
 type FragmentSpread struct {
     LineNum       int
+    Location      Location
     Name         *Name
     Directives []*Directive
 }
@@ -188,6 +195,7 @@ func (fragmentSpread *FragmentSpread) GetSelectionSet() *SelectionSet {
 
 type FragmentDefinition struct {
     LineNum          int
+    Location         Location
     Name            *Name
     TypeCondition   *Name
     Directives    []*Directive
@@ -200,6 +208,7 @@ func (fragmentDefinition *FragmentDefinition) GetDefinitionType() string {
 
 type InlineFragment struct {
     LineNum          int 
+    Location         Location
     TypeCondition    *Name
     Directives     []*Directive
     SelectionSet     *SelectionSet
@@ -211,6 +220,7 @@ func (inlineFragment *InlineFragment) GetSelectionSet() *SelectionSet {
 
 type TypeCondition struct {
     LineNum     int
+    Location    Location
     NamedType  *NamedType
 }
 
@@ -240,47 +250,56 @@ var _ Value = (*ObjectValue)(nil)
 type Variable *Name
 
 type IntValue struct {
-    LineNum int
-    Value   int
+    LineNum  int
+    Location Location
+    Value    int
 }
 
 type FloatValue struct {
-    LineNum int 
-    Value   float64
+    LineNum  int 
+    Location Location
+    Value    float64
 }
 
 type StringValue struct {
-    LineNum int
-    Value   string
+    LineNum  int
+    Location Location
+    Value    string
 }
 
 type BooleanValue struct {
-    LineNum int 
-    Value   bool
+    LineNum  int 
+    Location Location
+    Value    bool
 }
 
 type NullValue struct {
-    LineNum int
-    Value   interface{}
+    LineNum  int
+    Location Location
+    Value    interface{}
 }
 
 type EnumValue struct {
-    LineNum int 
-    Value   *Name
+    LineNum  int 
+    Location Location
+    Value    *Name
 }
 
 type ListValue struct {
-    LineNum int 
-    Value   []Value
+    LineNum  int 
+    Location Location
+    Value    []Value
 }
 
 type ObjectValue struct {
-    LineNum int 
-    Value []*ObjectField
+    LineNum  int 
+    Location Location
+    Value    []*ObjectField
 }
 
 type ObjectField struct {
     LineNum    int
+    Location   Location
     Name       *Name 
     Value      Value
 }
@@ -296,6 +315,7 @@ type ObjectField struct {
  */
 type VariableDefinition struct {
     LineNum        int 
+    Location       Location
     Variable      *Name
     Type           Type
     DefaultValue   Value
@@ -321,11 +341,13 @@ type NamedType Name
 
 type ListType struct {
     LineNum     int
+    Location    Location
     Type        []Type
 }
 
 type NonNullType struct {
     LineNum     int
+    Location    Location
     Type        Type
 }
 
@@ -338,6 +360,7 @@ type NonNullType struct {
 
 type Directive struct {
     LineNum     int
+    Location    Location
     Name        *Name
     Arguments   []*Argument
 }
@@ -361,6 +384,7 @@ const SchemaDefinitionType = "SchemaDefinition"
 
 type SchemaDefinition struct {
     LineNum                     int 
+    Location                    Location
     Directives               []*Directive
     OperationTypeDefinitions []*OperationTypeDefinition
 }
@@ -373,6 +397,7 @@ const SchemaExtensionType = "SchemaExtension"
 
 type SchemaExtension struct {
     LineNum                    int 
+    Location                   Location
     Directives               []*Directive 
     OperationTypeDefinitions []*OperationTypeDefinition
 }
@@ -388,6 +413,7 @@ func (schemaExtension *SchemaExtension) GetDefinitionType() string {
  */
 type OperationTypeDefinition struct {
     LineNum            int 
+    Location           Location
     OperationType      int
     OperationTypeName  string 
     NamedType         *NamedType
@@ -409,6 +435,7 @@ type OperationTypeDefinition struct {
  */
 type ScalarTypeDefinition struct {
     LineNum       int 
+    Location      Location
     Description   StringValue
     Name         *Name 
     Directives []*Directive
@@ -422,6 +449,7 @@ const TypeExtensionType = "TypeExtension"
 
 type ScalarTypeExtension struct {
     LineNum       int 
+    Location      Location
     Name         *Name 
     Directives []*Directive
 }
@@ -437,6 +465,7 @@ func (scalarTypeExtension *ScalarTypeExtension) GetDefinitionType() string {
  */
 type ObjectTypeDefinition struct {
     LineNum                 int 
+    Location                Location
     Description             StringValue
     Name                   *Name 
     ImplementsInterfaces   *ImplementsInterfaces
@@ -450,6 +479,7 @@ func (objectTypeDefinition *ObjectTypeDefinition) GetDefinitionType() string {
 
 type ObjectTypeExtension struct {
     LineNum                 int 
+    Location                Location
     Name                   *Name 
     ImplementsInterfaces   *ImplementsInterfaces
     Directives           []*Directive
@@ -467,6 +497,7 @@ func (objectTypeExtension *ObjectTypeExtension) GetDefinitionType() string {
  */
 type ImplementsInterfaces struct {
     LineNum       int 
+    Location      Location
     NamedTypes []*NamedType
 }
 
@@ -478,6 +509,7 @@ type ImplementsInterfaces struct {
  */
 type InterfaceTypeDefinition struct {
     LineNum             int 
+    Location            Location
     Description         StringValue
     Name               *Name 
     Directives       []*Directive
@@ -490,6 +522,7 @@ func (interfaceTypeDefinition *InterfaceTypeDefinition)GetDefinitionType() strin
 
 type InterfaceTypeExtension struct {
     LineNum             int 
+    Location            Location
     Name               *Name 
     Directives       []*Directive
     FieldsDefinition []*FieldDefinition
@@ -507,6 +540,7 @@ func (interfaceTypeExtension *InterfaceTypeExtension) GetDefinitionType() string
  */
 type UnionTypeDefinition struct {
     LineNum             int
+    Location            Location
     Description         StringValue
     Name               *Name 
     Directives       []*Directive
@@ -519,11 +553,13 @@ func (unionTypeDefinition *UnionTypeDefinition) GetDefinitionType() string {
 
 type UnionMemberTypes struct {
     LineNum       int
+    Location      Location
     NamedTypes []*NamedType
 }
 
 type UnionTypeExtension struct {
     LineNum             int
+    Location            Location
     Name               *Name
     Directives       []*Directive
     UnionMemberTypes   *UnionMemberTypes
@@ -543,6 +579,7 @@ func (unionTypeExtension *UnionTypeExtension) GetDefinitionType() string {
  */
 type EnumTypeDefinition struct {
     LineNum                 int 
+    Location                Location
     Name                   *Name
     Description             StringValue
     Directives           []*Directive
@@ -555,6 +592,7 @@ func (enumTypeDefinition EnumTypeDefinition) GetDefinitionType() string {
 
 type EnumValueDefinition struct {
     LineNum        int
+    Location       Location
     Description    StringValue 
     EnumValue      EnumValue
     Directives  []*Directive
@@ -562,6 +600,7 @@ type EnumValueDefinition struct {
 
 type EnumTypeExtension struct {
     LineNum                 int
+    Location                Location
     Name                   *Name
     Directives           []*Directive
     EnumValuesDefinition []*EnumValueDefinition
@@ -580,6 +619,7 @@ func (enumTypeExtension *EnumTypeExtension) GetDefinitionType() string {
  */
 type InputObjectTypeDefinition struct {
     LineNum                  int
+    Location                 Location
     Description              StringValue
     Name                    *Name 
     Directives            []*Directive
@@ -592,6 +632,7 @@ func (inputObjectTypeDefinition InputObjectTypeDefinition) GetDefinitionType() s
 
 type InputObjectTypeExtension struct {
     LineNum                   int
+    Location                  Location
     Name                     *Name
     Directives             []*Directive
     InputFieldsDefinition  []*InputValueDefinition
@@ -615,6 +656,7 @@ const DirectiveDefinitionType = "DirectiveDefinition"
 
 type DirectiveDefinition struct {
     LineNum                int
+    Location               Location
     Description            StringValue
     Name                  *Name 
     ArgumentsDefinition []*InputValueDefinition
@@ -638,6 +680,7 @@ type DirectiveLocation string
  */
 type FieldDefinition struct {
     LineNum                int
+    Location               Location
     Description            StringValue
     Name                  *Name 
     ArgumentsDefinition []*InputValueDefinition
@@ -657,6 +700,7 @@ type ArgumentsDefinition []*InputValueDefinition
 
 type InputValueDefinition struct {
     LineNum         int 
+    Location        Location
     Description     StringValue
     Name           *Name 
     Type            Type
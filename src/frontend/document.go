@@ -17,25 +17,51 @@ func (document *Document) GetDefinitions() []Definition {
     return document.Definitions
 }
 
+// get name mapped FragmentDefinitions
+func (document *Document) GetFragmentDefinitions() map[string]*FragmentDefinition {
+    fragmentDefinitions := make(map[string]*FragmentDefinition)
+    for _, definition := range document.Definitions {
+        if definition.GetDefinitionType() == FragmentDefinitionType {
+            fragmentDefinition := definition.(*FragmentDefinition)
+            fragmentDefinitions[fragmentDefinition.Name.Value] = fragmentDefinition
+        }
+    }
+    return fragmentDefinitions
+}
+
 func (document *Document) GetOperationDefinition() (*OperationDefinition, error) {
+    return document.GetOperationDefinitionByName("")
+}
+
+// pickup OperationDefinition by operation name, the operationName can be empty when document only have one operation
+func (document *Document) GetOperationDefinitionByName(operationName string) (*OperationDefinition, error) {
     var operationDefinition *OperationDefinition
     var hit int8
     hit = 0
     // pickup OperationDefinition
     for _, definition := range document.Definitions {
-        if definition.GetDefinitionType() == OperationDefinitionType {
-            operationDefinition = definition.(*OperationDefinition)
+        if definition.GetDefinitionType() != OperationDefinitionType {
+            continue
+        }
+        candidate := definition.(*OperationDefinition)
+        if operationName == "" {
+            operationDefinition = candidate
             hit ++
+            continue
+        }
+        if candidate.Name != nil && candidate.Name.Value == operationName {
+            return candidate, nil
         }
     }
     // check
+    if operationName != "" {
+        return nil, errors.New("GetOperationDefinitionByName(): unknown operation named '"+operationName+"'.")
+    }
     if hit == 0 {
-        return nil, errors.New("GetOperationDefinition(): input Document does not have OperationDefinition.")
+        return nil, errors.New("GetOperationDefinitionByName(): input Document does not have OperationDefinition.")
     }
     if hit > 1 {
-        return nil, errors.New("GetOperationDefinition(): multiple OperationDefinition detected, please check your GraphQL syntax.")
-
+        return nil, errors.New("GetOperationDefinitionByName(): must provide operation name if query contains multiple operations.")
     }
     return operationDefinition, nil
 }
-
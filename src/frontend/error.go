@@ -0,0 +1,57 @@
+// error.go
+
+package frontend
+
+import (
+    "fmt"
+    "strings"
+)
+
+/**
+ * SyntaxError
+ * returned by Compile when the document is not a valid GraphQL document.
+ * Expected and Got are the token names, Expected is empty when no single token was expected.
+ * Excerpt is the source line of error with a "^" marker under the column.
+ */
+type SyntaxError struct {
+    Message   string
+    Line      int
+    Column    int
+    Expected  string
+    Got       string
+    Excerpt   string
+}
+
+func (syntaxError *SyntaxError) Error() string {
+    return fmt.Sprintf("line %d, column %d: %s", syntaxError.Line, syntaxError.Column, syntaxError.Message)
+}
+
+func newSyntaxError(source string, location Location, message string, expected string, got string) *SyntaxError {
+    return &SyntaxError{message, location.Line, location.Column, expected, got, getSourceExcerpt(source, location)}
+}
+
+// get the source line at location and mark the column
+func getSourceExcerpt(source string, location Location) string {
+    if location.Offset < 0 || location.Offset > len(source) {
+        return ""
+    }
+    lineStart := strings.LastIndexAny(source[:location.Offset], "\r\n") + 1
+    lineEnd   := strings.IndexAny(source[location.Offset:], "\r\n")
+    if lineEnd < 0 {
+        lineEnd = len(source)
+    } else {
+        lineEnd += location.Offset
+    }
+    line := source[lineStart:lineEnd]
+    // keep tabs for marker alignment
+    var marker strings.Builder
+    for _, c := range source[lineStart:location.Offset] {
+        if c == '\t' {
+            marker.WriteRune('\t')
+        } else {
+            marker.WriteRune(' ')
+        }
+    }
+    marker.WriteRune('^')
+    return line + "\n" + marker.String()
+}
@@ -1,21 +1,37 @@
 // frontend.go
-// 
+//
 
 package frontend
 
-import (
-
-)
-
-func Compile(query string) (*Document, error) {
-	var document *Document
-	var err error
+/**
+ * Compile
+ * parse query into Document, any syntax error is returned as *SyntaxError, it never panics.
+ */
+func Compile(query string) (document *Document, err error) {
     lexer := NewLexer(query)
+    // lexer stops at unexpected token by panic *SyntaxError, any other panic is a bug and not recovered
+    defer func() {
+        if r := recover(); r != nil {
+            syntaxError, ok := r.(*SyntaxError)
+            if !ok {
+                panic(r)
+            }
+            document = nil
+            err      = syntaxError
+        }
+    }()
     if document, err = parseDocument(lexer); err != nil {
-    	return nil, err
+        return nil, newSyntaxError(lexer.source, getErrorLocation(lexer), err.Error(), "", "")
     }
     // set EOF for document end
-    lexer.NextTokenIs(TOKEN_EOF) 
+    lexer.NextTokenIs(TOKEN_EOF)
     return document, nil
 }
 
+// get location of the token which caused the error, the look ahead token first
+func getErrorLocation(lexer *Lexer) Location {
+    if lexer.nextTokenLineNum > 0 {
+        return lexer.nextTokenLocation
+    }
+    return lexer.location
+}
@@ -0,0 +1,128 @@
+// frontend_test.go
+
+package frontend
+
+import (
+    "math/rand"
+    "strings"
+    "testing"
+    "time"
+)
+
+/**
+ * Invalid document corpus
+ * each document must fail with a SyntaxError at line:column, including the inputs which used to
+ * make parseType and parseListType loop forever.
+ */
+var syntaxErrorTests = []struct {
+    query   string
+    line    int
+    column  int
+    message string
+}{
+    {"query Q($a: [) { a }", 1, 14, "unexpected ')', expected Type."},
+    {"query Q($a: [", 1, 14, "unexpected 'EOF', expected Type."},
+    {"query Q($a: [Int Int]) { a }", 1, 18, "unexpected 'Int', expected ']'."},
+    {"input I { a: }", 1, 14, "unexpected '}', expected Type."},
+    {"union U =", 1, 10, "unexpected 'EOF', expected Name."},
+    {"union U = | ", 1, 13, "unexpected 'EOF', expected Name."},
+    {"{ a ", 1, 5, "unexpected 'EOF', expected Name."},
+    {"{ a(x: ) }", 1, 8, "parseValue(): unexpected value type ')'."},
+    {"{ a(x: \"abc) }", 1, 9, "unterminated string, expected '\"'."},
+    {"{ a(x: \"\"\"abc) }", 1, 11, "unterminated string, expected '\"\"\"'."},
+    {"{ a { b } } }", 1, 13, "parseDefinition(): can not parse Definition."},
+    {"query Q($: Int) { a }", 1, 10, "unexpected ':', expected Name."},
+    {"{ a: }", 1, 6, "unexpected '}', expected Name."},
+    {"", 1, 1, "parseDefinitions(): document must contain at least one definition."},
+    {"query", 1, 6, "unexpected 'EOF', expected '{'."},
+    {"{ a(x: [1, {b: }]) }", 1, 16, "parseValue(): unexpected value type '}'."},
+    {"type T { f(a: Int = ): Int }", 1, 21, "parseValue(): unexpected value type ')'."},
+    {"{ a @ }", 1, 7, "unexpected '}', expected Name."},
+    {"{\r\n  a(x: )\r\n}", 2, 8, "parseValue(): unexpected value type ')'."},
+    {"{\r  a(x: )\r}", 2, 8, "parseValue(): unexpected value type ')'."},
+    {"\"\"\"\r\n\r\n\"\"\" type T { a: }", 3, 17, "unexpected '}', expected Type."},
+}
+
+// compile query in goroutine, a parser hang fails the test instead of blocking it
+func compileWithTimeout(t *testing.T, query string) (*Document, error) {
+    type result struct {
+        document *Document
+        err      error
+    }
+    done := make(chan result, 1)
+    go func() {
+        document, err := Compile(query)
+        done <- result{document, err}
+    }()
+    select {
+    case r := <-done:
+        return r.document, r.err
+    case <-time.After(5 * time.Second):
+        t.Fatalf("Compile(%q) did not return.", query)
+        return nil, nil
+    }
+}
+
+func TestCompileSyntaxError(t *testing.T) {
+    for _, test := range syntaxErrorTests {
+        _, err := compileWithTimeout(t, test.query)
+        syntaxError, ok := err.(*SyntaxError)
+        if !ok {
+            t.Errorf("Compile(%q) error = %v, want *SyntaxError", test.query, err)
+            continue
+        }
+        if syntaxError.Line != test.line || syntaxError.Column != test.column || syntaxError.Message != test.message {
+            t.Errorf("Compile(%q) error = %d:%d %q, want %d:%d %q", test.query, syntaxError.Line, syntaxError.Column, syntaxError.Message, test.line, test.column, test.message)
+        }
+    }
+}
+
+/**
+ * Token fuzz
+ * random token sequences must never panic out of Compile, and every document which compiles must
+ * print to a document that compiles to the same output.
+ */
+var fuzzTokens = []string{
+    "{", "}", "(", ")", "[", "]", ":", "$", "@", "!", "...", "=", "|", "&",
+    "\"s\"", "\"\"\"b\"\"\"", "\"\"\"x\r\ny\"\"\"", "1", "2.5", "-3", "a", "b",
+    "query", "mutation", "subscription", "fragment", "on", "type", "interface", "union", "enum",
+    "input", "scalar", "schema", "directive", "extend", "implements", "repeatable", "true", "null",
+    "#c\n", "\n", "\r\n", "\r", ",", "$a:", "query Q(", "f:", "| A", "@d on",
+}
+
+func TestCompileFuzz(t *testing.T) {
+    random := rand.New(rand.NewSource(1))
+    iterations := 50000
+    if testing.Short() {
+        iterations = 5000
+    }
+    compiled := 0
+    for i := 0; i < iterations; i++ {
+        tokens := make([]string, random.Intn(25))
+        for j := range tokens {
+            tokens[j] = fuzzTokens[random.Intn(len(fuzzTokens))]
+        }
+        query := strings.Join(tokens, " ")
+        document, err := compileWithTimeout(t, query)
+        if err != nil {
+            if _, ok := err.(*SyntaxError); !ok {
+                t.Fatalf("Compile(%q) error = %v, want *SyntaxError", query, err)
+            }
+            continue
+        }
+        compiled++
+        printed := Print(document)
+        for _, output := range []string{printed, PrintCompact(document)} {
+            reparsed, err := compileWithTimeout(t, output)
+            if err != nil {
+                t.Fatalf("Compile(Print(%q)) error: %v\n%s", query, err, output)
+            }
+            if reprinted := Print(reparsed); reprinted != printed {
+                t.Fatalf("Print round trip of %q changed:\n%s\nwant:\n%s", query, reprinted, printed)
+            }
+        }
+    }
+    if compiled == 0 {
+        t.Errorf("no fuzz document compiled.")
+    }
+}
@@ -6,6 +6,7 @@ import (
     "strings"
     "regexp"
     "fmt"
+    "unicode/utf8"
 )
 
 // token const
@@ -134,62 +135,73 @@ var regexIdentifier = regexp.MustCompile(`^[_\d\w]+`)
 
 // lexer struct
 type Lexer struct {
-    document            string // graphql document
-    lineNum             int    // current line number
+    document            string   // graphql document
+    lineNum             int      // current line number
     nextToken           string 
     nextTokenType       int 
     nextTokenLineNum    int
+    source              string   // full graphql document, for column counting
+    offset              int      // current byte offset in source
+    lineStart           int      // byte offset of current line start
+    location            Location // location of the last returned token
+    nextTokenLocation   Location
 }
 
 func NewLexer(document string) *Lexer {
-    return &Lexer{document, 1, "", 0, 0} // start at line 1 in default.
+    return &Lexer{document, 1, "", 0, 0, document, 0, 0, Location{1, 1, 0}, Location{}} // start at line 1 in default.
 }
 
 func (lexer *Lexer) GetLineNum() int {
     return lexer.lineNum
 }
 
-func (lexer *Lexer) NextTokenIs(tokenType int) (lineNum int, token string) {
+// get location of the last returned token
+func (lexer *Lexer) GetLocation() Location {
+    return lexer.location
+}
+
+// get location of the next token, the token will not be consumed
+func (lexer *Lexer) GetNextTokenLocation() Location {
+    lexer.LookAhead()
+    return lexer.nextTokenLocation
+}
+
+// get location of current scanning position
+func (lexer *Lexer) getCurrentLocation() Location {
+    column := utf8.RuneCountInString(lexer.source[lexer.lineStart:lexer.offset]) + 1
+    return Location{lexer.lineNum, column, lexer.offset}
+}
 
+func (lexer *Lexer) NextTokenIs(tokenType int) (lineNum int, token string) {
     nowLineNum, nowTokenType, nowToken := lexer.GetNextToken()
-    fmt.Printf("    lexer.NextTokenIs( expect:'%v'==>'%v' -> Got:'%v'==>'%v' )\n", tokenType, tokenNameMap[tokenType], nowTokenType, tokenNameMap[nowTokenType])
     // syntax error
     if tokenType != nowTokenType {
-        fmt.Println("\n\n\033[05m\033[41;37m                    OOOOOOOOOPS! TOKEN EXCEPT FAILED                    \033[0m\n")
-        err := fmt.Sprintf("line %d: syntax error near '%s'.", lexer.GetLineNum(), nowToken) 
-        fmt.Println("- [Lexer Dump] -------------------------------------------------------\n")
-        fmt.Printf("document:\n\n\033[33m%v\033[0m\n\n", lexer.document)
-        fmt.Printf("lineNum:          \033[33m%v\033[0m\n", lexer.lineNum)
-        fmt.Printf("nowToken:         \033[33m%v\033[0m\n", nowToken)
-        fmt.Printf("nextToken:        \033[33m%v\033[0m\n", lexer.nextToken)
-        fmt.Printf("nextTokenType:    \033[33m%v\033[0m\n", lexer.nextTokenType)
-        fmt.Printf("nextTokenLineNum: \033[33m%v\033[0m\n", lexer.nextTokenLineNum)
-        fmt.Printf("\n---------------------------------------------------------------------\n\n")
-        panic(err)
+        message := fmt.Sprintf("unexpected '%s', expected '%s'.", nowToken, tokenNameMap[tokenType])
+        lexer.syntaxError(lexer.location, message, tokenNameMap[tokenType], nowToken)
     }
     return nowLineNum, nowToken
 }
 
+// stop lexing with a SyntaxError, it will be recovered and returned by Compile()
+func (lexer *Lexer) syntaxError(location Location, message string, expected string, got string) {
+    panic(newSyntaxError(lexer.source, location, message, expected, got))
+}
+
 func (lexer *Lexer) LookAhead() int {
     // lexer.nextToken* already setted
-    // fmt.Printf("\033[36mLookAhead().lexer.nextTokenLineNum: %v\033[0m\n", lexer.nextTokenLineNum)
     if lexer.nextTokenLineNum > 0 {
-    // fmt.Printf("\033[36mLookAhead().lexer.nextTokenType: %v : %v\033[0m\n", lexer.nextTokenType, tokenNameMap[lexer.nextTokenType])
-
         return lexer.nextTokenType
     }
     // set it
     nowLineNum                := lexer.lineNum
-    // fmt.Printf("\033[36mLookAhead().lineNum: %v \033[0m\n", lexer.lineNum)
-
+    nowLocation               := lexer.location
     lineNum, tokenType, token := lexer.GetNextToken()
-    // fmt.Printf("\033[36mLookAhead().lineNum: %v \033[0m\n", lexer.lineNum)
-    
     lexer.lineNum              = nowLineNum
+    lexer.nextTokenLocation    = lexer.location
+    lexer.location             = nowLocation
     lexer.nextTokenLineNum     = lineNum
     lexer.nextTokenType        = tokenType
     lexer.nextToken            = token
-    // fmt.Printf("\033[36mLookAhead().tokenType: %v : %v\033[0m\n", tokenType, tokenNameMap[tokenType])
     return tokenType
 }
 
@@ -199,6 +211,31 @@ func (lexer *Lexer) nextDocumentIs(s string) bool {
 
 func (lexer *Lexer) skipDocument(n int) {
     lexer.document = lexer.document[n:]
+    lexer.offset  += n
+}
+
+// mark a new line started at current offset
+func (lexer *Lexer) newLine() {
+    lexer.lineNum  += 1
+    lexer.lineStart = lexer.offset
+}
+
+// skip n bytes which may contain line terminators, "\r\n", "\r" and "\n" are all line terminators
+func (lexer *Lexer) skipDocumentLines(n int) {
+    for n > 0 {
+        if n >= 2 && lexer.nextDocumentIs("\r\n") {
+            lexer.skipDocument(2)
+            lexer.newLine()
+            n -= 2
+            continue
+        }
+        c := lexer.document[0]
+        lexer.skipDocument(1)
+        if c == '\r' || c == '\n' {
+            lexer.newLine()
+        }
+        n -= 1
+    }
 }
 
 func (lexer *Lexer) skipIgnored() {
@@ -229,19 +266,19 @@ func (lexer *Lexer) skipIgnored() {
     }
     // matching
     for len(lexer.document) > 0 {
-        if lexer.nextDocumentIs("\r\n") || lexer.nextDocumentIs("\n\r") {
+        if lexer.nextDocumentIs("\r\n") {
             lexer.skipDocument(2)
-            lexer.lineNum += 1
+            lexer.newLine()
         } else if isNewLine(lexer.document[0]) {
             lexer.skipDocument(1)
-            lexer.lineNum += 1
+            lexer.newLine()
         } else if isWhiteSpace(lexer.document[0]) {
             lexer.skipDocument(1)
         } else if isComma(lexer.document[0]) {
             lexer.skipDocument(1)
         } else if isComment(lexer.document[0]) {
             lexer.skipDocument(1)
-            for !isNewLine(lexer.document[0]) {
+            for len(lexer.document) > 0 && !isNewLine(lexer.document[0]) {
                 lexer.skipDocument(1)
             }
         } else {
@@ -256,7 +293,9 @@ func (lexer *Lexer) scan(regexp *regexp.Regexp) string {
         lexer.skipDocument(len(token))
         return token
     }
-    panic("unreachable!")
+    location  := lexer.getCurrentLocation()
+    symbol, _ := utf8.DecodeRuneInString(lexer.document)
+    lexer.syntaxError(location, fmt.Sprintf("unexpected symbol near '%c'.", symbol), "", string(symbol))
     return ""
 }
 
@@ -264,10 +303,12 @@ func (lexer *Lexer) scan(regexp *regexp.Regexp) string {
 func (lexer *Lexer) scanBeforeToken(token string) string {
     s := strings.Split(lexer.document, token)
     if len(s) < 2 {
-        panic("unreachable!")
+        location := lexer.getCurrentLocation()
+        lexer.syntaxError(location, fmt.Sprintf("unterminated string, expected '%s'.", token), token, tokenNameMap[TOKEN_EOF])
         return ""
     }
-    lexer.skipDocument(len(s[0]))
+    // block string may contains line terminators
+    lexer.skipDocumentLines(len(s[0]))
     return s[0]
 }
 
@@ -287,6 +328,7 @@ func (lexer *Lexer) GetNextToken() (lineNum int, tokenType int, token string) {
         tokenType              = lexer.nextTokenType
         token                  = lexer.nextToken
         lexer.lineNum          = lexer.nextTokenLineNum
+        lexer.location         = lexer.nextTokenLocation
         lexer.nextTokenLineNum = 0
         return
     }
@@ -298,11 +340,11 @@ func (lexer *Lexer) GetNextToken() (lineNum int, tokenType int, token string) {
 func (lexer *Lexer) MatchToken() (lineNum int, tokenType int, token string) {
     // skip spaces
     lexer.skipIgnored()
+    lexer.location = lexer.getCurrentLocation()
     // finish
     if len(lexer.document) == 0 {
         return lexer.lineNum, TOKEN_EOF, tokenNameMap[TOKEN_EOF]
     }
-    fmt.Printf("now lexer: %c\n", lexer.document[0])
     // check token
     switch lexer.document[0] {
     case '!' :
@@ -381,14 +423,20 @@ func (lexer *Lexer) MatchToken() (lineNum int, tokenType int, token string) {
     }
 
     // unexpected symbol
-    err := fmt.Sprintf("line %d: unexpected symbol near '%q'.", lexer.lineNum, lexer.document[0])
-    panic(err)
+    symbol, _ := utf8.DecodeRuneInString(lexer.document)
+    message   := fmt.Sprintf("unexpected symbol near '%c'.", symbol)
+    lexer.syntaxError(lexer.location, message, "", string(symbol))
     return 
 }
 
 
 
 
+// identifier and keywords are all Name in GraphQL
+func isNameToken(tokenType int) bool {
+    return tokenType == TOKEN_IDENTIFIER || tokenType >= TOKEN_QUERY && tokenType <= TOKEN_ON
+}
+
 func isDigit(c byte) bool {
     return c >= '0' && c <= '9'
 }
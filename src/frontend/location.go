@@ -0,0 +1,15 @@
+// location.go
+
+package frontend
+
+/**
+ * Location
+ * the source position of a token or AST node start.
+ * Line and Column are 1-indexed, Column counts characters (not bytes) from the line start.
+ * Offset is the byte offset from the document start.
+ */
+type Location struct {
+    Line    int
+    Column  int
+    Offset  int
+}
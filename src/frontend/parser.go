@@ -10,9 +10,6 @@ import (
     // "os"
     "strconv"
     "errors"
-
-    // "github.com/davecgh/go-spew/spew"
-
 )
 
 
@@ -39,9 +36,10 @@ import (
  *
  */
 func parseName(lexer *Lexer) (*Name, error) {
-    fmt.Printf("\033[31m[INTO] func parseName  \033[0m\n")
-
-    lineNum, _, token := lexer.GetNextToken()
+    lineNum, tokenType, token := lexer.GetNextToken()
+    if !isNameToken(tokenType) {
+        lexer.syntaxError(lexer.GetLocation(), fmt.Sprintf("unexpected '%s', expected Name.", token), "Name", token)
+    }
     for _, b := range []rune(token) {
         if (b == '_' || 
             b >= 'a' && b <= 'z' ||
@@ -53,57 +51,96 @@ func parseName(lexer *Lexer) (*Name, error) {
             return nil, errors.New(err)
         }
     }
-    return &Name{lineNum, token}, nil
+    return &Name{lineNum, lexer.GetLocation(), token}, nil
 }
 
 func parseNumberValue(lexer *Lexer) (Value, error) {
-    fmt.Printf("\033[31m[INTO] func parseNumberValue  \033[0m\n")
-
     var isFloat = func(token string) bool {
-        i := strings.Index(token, ".")
-        if i < 0 {
-            return false
+        if strings.HasPrefix(token, "0x") || strings.HasPrefix(token, "0X") {
+            return strings.ContainsAny(token, ".pP")
         }
-        return true
+        return strings.ContainsAny(token, ".eE")
     }
 
     _, token := lexer.NextTokenIs(TOKEN_NUMBER)
     if isFloat(token) {
-        num, _ := strconv.ParseFloat(token, 64)
-        return FloatValue{lexer.GetLineNum(), num}, nil
+        num, err := strconv.ParseFloat(token, 64)
+        if err != nil {
+            return nil, errors.New("parseNumberValue(): '" + token + "' is not a valid Float value.")
+        }
+        return FloatValue{lexer.GetLineNum(), lexer.GetLocation(), num}, nil
     } else {
-        num, _ := strconv.Atoi(token)
-        return IntValue{lexer.GetLineNum(), num}, nil
+        num, err := strconv.ParseInt(token, 10, 64)
+        if err != nil {
+            return nil, errors.New("parseNumberValue(): '" + token + "' is not a valid Int value.")
+        }
+        return IntValue{lexer.GetLineNum(), lexer.GetLocation(), int(num)}, nil
     }
     return nil, nil
 }
 
 func parseStringValue(lexer *Lexer) (StringValue, error) {
-    lineNum := lexer.GetLineNum()
+    lineNum  := lexer.GetLineNum()
+    location := lexer.GetNextTokenLocation()
     if lexer.LookAhead() == TOKEN_HEXQUOTE {
         lexer.NextTokenIs(TOKEN_HEXQUOTE)
-        return StringValue{lineNum, ""}, nil
+        return StringValue{lineNum, location, ""}, nil
     }
     if lexer.LookAhead() == TOKEN_DUOQUOTE {
         lexer.NextTokenIs(TOKEN_DUOQUOTE)
-        return StringValue{lineNum, ""}, nil
+        return StringValue{lineNum, location, ""}, nil
     }
     if lexer.LookAhead() == TOKEN_TRIQUOTE {
         lexer.NextTokenIs(TOKEN_TRIQUOTE)
         str := lexer.scanBeforeToken(tokenNameMap[TOKEN_TRIQUOTE])
         lexer.NextTokenIs(TOKEN_TRIQUOTE)
-        return StringValue{lineNum, str}, nil
+        return StringValue{lineNum, location, getBlockStringValue(str)}, nil
     }
     if lexer.LookAhead() == TOKEN_QUOTE {
         lexer.NextTokenIs(TOKEN_QUOTE)
         str := lexer.scanBeforeToken(tokenNameMap[TOKEN_QUOTE])
         lexer.NextTokenIs(TOKEN_QUOTE)
-        return StringValue{lineNum, str}, nil
+        return StringValue{lineNum, location, str}, nil
     }
     err := "not a StringValue"
-    return StringValue{lineNum, ""}, errors.New(err)
+    return StringValue{lineNum, location, ""}, errors.New(err)
 }
 
+/**
+ * BlockStringValue
+ * remove the common indentation of block string lines (except the first line), and the leading
+ * and trailing blank lines, so block string can be indented with the surrounding document.
+ */
+func getBlockStringValue(rawValue string) string {
+    rawValue = strings.ReplaceAll(strings.ReplaceAll(rawValue, "\r\n", "\n"), "\r", "\n")
+    lines := strings.Split(rawValue, "\n")
+    commonIndent := -1
+    for _, line := range lines[1:] {
+        indent := len(line) - len(strings.TrimLeft(line, " \t"))
+        if indent < len(line) && (commonIndent < 0 || indent < commonIndent) {
+            commonIndent = indent
+        }
+    }
+    if commonIndent > 0 {
+        for i := 1; i < len(lines); i++ {
+            if len(lines[i]) < commonIndent {
+                lines[i] = ""
+            } else {
+                lines[i] = lines[i][commonIndent:]
+            }
+        }
+    }
+    isBlank := func(line string) bool {
+        return strings.TrimLeft(line, " \t") == ""
+    }
+    for len(lines) > 0 && isBlank(lines[0]) {
+        lines = lines[1:]
+    }
+    for len(lines) > 0 && isBlank(lines[len(lines)-1]) {
+        lines = lines[:len(lines)-1]
+    }
+    return strings.Join(lines, "\n")
+}
 
 /**
  * Document Expression
@@ -111,9 +148,6 @@ func parseStringValue(lexer *Lexer) (StringValue, error) {
  * 
  */
 func parseDocument(lexer *Lexer) (*Document, error) {
-    fmt.Println("\n\n\033[33m////////////////////////////////////////// Parser Start ///////////////////////////////////////\033[0m\n")
-    fmt.Printf("\033[31m[INTO] func parseDocument  \033[0m\n")
-
     var document Document
     var err      error
 
@@ -141,8 +175,6 @@ func isDocumentEnd(tokenType int) bool {
  *
  */
 func parseDefinitions(lexer *Lexer) ([]Definition, error) {
-    fmt.Printf("\033[31m[INTO] func parseDefinitions  \033[0m\n")
-
     var definitions []Definition
     for !isDocumentEnd(lexer.LookAhead()) {
         var definition Definition
@@ -150,15 +182,15 @@ func parseDefinitions(lexer *Lexer) ([]Definition, error) {
         if definition, err = parseDefinition(lexer); err != nil {
             return nil, err
         }
-        fmt.Printf("- definitions ------\n%v\n", definition)
         definitions = append(definitions, definition)
     }   
+    if len(definitions) == 0 {
+        return nil, errors.New("parseDefinitions(): document must contain at least one definition.")
+    }
     return definitions, nil
 }
 
 func parseDefinition(lexer *Lexer) (Definition, error) {
-    fmt.Printf("\033[31m[INTO] func parseDefinition  \033[0m\n")
-    
     switch lexer.LookAhead() {
     /**
      * Definition: 
@@ -228,13 +260,12 @@ func parseDefinition(lexer *Lexer) (Definition, error) {
  *
  */
 func parseOperationDefinition(lexer *Lexer) (*OperationDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseOperationDefinition  \033[0m\n")
-
     var operationDefinition OperationDefinition
     var err                 error
 
     // LineNum
     operationDefinition.LineNum = lexer.GetLineNum()
+    operationDefinition.Location = lexer.GetNextTokenLocation()
     // short query operation
     if lexer.LookAhead() == TOKEN_LEFT_BRACE {
         goto SHORT_QUERY_OPERATION
@@ -274,8 +305,6 @@ func parseOperationDefinition(lexer *Lexer) (*OperationDefinition, error) {
 }
 
 func parseOperationType(lexer *Lexer) (int, string) {
-    fmt.Printf("\033[31m[INTO] func parseOperationType  \033[0m\n")
-
     var operation int
     switch lexer.LookAhead() {
     case TOKEN_QUERY:        // operation "query"
@@ -301,12 +330,11 @@ func parseOperationType(lexer *Lexer) (int, string) {
  * 
  */
 func parseSelectionSet(lexer *Lexer) (*SelectionSet, error) {
-    fmt.Printf("\033[31m[INTO] func parseSelectionSet  \033[0m\n")
-
     var selectionSet SelectionSet
 
     // LineNum
     selectionSet.LineNum = lexer.GetLineNum() 
+    selectionSet.Location = lexer.GetNextTokenLocation()
     // "{"
     lexer.NextTokenIs(TOKEN_LEFT_BRACE)
     // Selection+
@@ -324,8 +352,6 @@ func parseSelectionSet(lexer *Lexer) (*SelectionSet, error) {
 }
 
 func parseSelection(lexer *Lexer) (interface{}, error) {
-    fmt.Printf("\033[31m[INTO] func parseSelection  \033[0m\n")
-
     switch lexer.LookAhead() {
     case TOKEN_DOTS:
         lexer.NextTokenIs(TOKEN_DOTS)
@@ -348,13 +374,12 @@ func parseSelection(lexer *Lexer) (interface{}, error) {
  * 
  */
 func parseField(lexer *Lexer) (*Field, error) {
-    fmt.Printf("\033[31m[INTO] func parseField  \033[0m\n")
-
     var field           Field
     var err             error
 
     // lineNum
     field.LineNum = lexer.GetLineNum()
+    field.Location = lexer.GetNextTokenLocation()
 
     //  Alias & Name
     var name *Name
@@ -364,7 +389,7 @@ func parseField(lexer *Lexer) (*Field, error) {
     if lexer.LookAhead() == TOKEN_COLON { // suffix is ":", it's Alias
         // ":"
         lexer.NextTokenIs(TOKEN_COLON)
-        field.Alias = &Alias{lexer.GetLineNum(), name}
+        field.Alias = &Alias{name.LineNum, name.Location, name}
         if field.Name, err = parseName(lexer); err != nil {
             return nil, err
         }
@@ -388,11 +413,9 @@ func parseField(lexer *Lexer) (*Field, error) {
 
     // SelectionSet
     if lexer.LookAhead() == TOKEN_LEFT_BRACE {
-        fmt.Printf("\033[33m into more SelectionSet: \033[0m\n")
         if field.SelectionSet, err = parseSelectionSet(lexer); err != nil {
             return nil, err
         }
-        fmt.Printf("\033[33m out more SelectionSet: \033[0m\n")
     }
     return &field, nil
 }
@@ -404,8 +427,6 @@ func parseField(lexer *Lexer) (*Field, error) {
  *
  */
 func parseArguments(lexer *Lexer) ([]*Argument, error) {
-    fmt.Printf("\033[31m[INTO] func parseArguments  \033[0m\n")
-
     var arguments []*Argument
     var argument    *Argument
     var err          error 
@@ -422,13 +443,12 @@ func parseArguments(lexer *Lexer) ([]*Argument, error) {
 }
 
 func parseArgument(lexer *Lexer) (*Argument, error) {
-    fmt.Printf("\033[31m[INTO] func parseArgument  \033[0m\n")
-
     var argument Argument
     var err      error
 
     // LineNum
     argument.LineNum = lexer.GetLineNum()
+    argument.Location = lexer.GetNextTokenLocation()
     // Name
     if argument.Name, err = parseName(lexer); err != nil {
         return nil, err
@@ -452,11 +472,12 @@ func parseArgument(lexer *Lexer) (*Argument, error) {
  *
  */
 func parseFragmentSpread(lexer *Lexer) (*FragmentSpread, error) {
-    fmt.Printf("\033[31m[INTO] func parseFragmentSpread  \033[0m\n")
-
     var fragmentSpread FragmentSpread
     var err            error
 
+    // LineNum
+    fragmentSpread.LineNum = lexer.GetLineNum()
+    fragmentSpread.Location = lexer.GetLocation()
     // "..." finished at parseSelection()
     // FragmentName
     if fragmentSpread.Name, err = parseFragmentName(lexer); err != nil {
@@ -472,11 +493,12 @@ func parseFragmentSpread(lexer *Lexer) (*FragmentSpread, error) {
 }
 
 func parseInlineFragment(lexer *Lexer) (*InlineFragment, error) {
-    fmt.Printf("\033[31m[INTO] func parseInlineFragment  \033[0m\n")
-
     var inlineFragment InlineFragment
     var err            error
 
+    // LineNum
+    inlineFragment.LineNum = lexer.GetLineNum()
+    inlineFragment.Location = lexer.GetLocation()
     // "..." finished at parseSelection()
     // TypeCondition?
     if lexer.LookAhead() == TOKEN_ON {
@@ -499,11 +521,12 @@ func parseInlineFragment(lexer *Lexer) (*InlineFragment, error) {
 
 
 func parseFragmentDefinition(lexer *Lexer) (*FragmentDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseFragmentDefinition  \033[0m\n")
-
     var fragmentDefinition FragmentDefinition
     var err                error
 
+    // LineNum
+    fragmentDefinition.LineNum = lexer.GetLineNum()
+    fragmentDefinition.Location = lexer.GetNextTokenLocation()
     // "fragment"
     lexer.NextTokenIs(TOKEN_FRAGMENT)
     // FragmentName
@@ -528,8 +551,6 @@ func parseFragmentDefinition(lexer *Lexer) (*FragmentDefinition, error) {
 }
 
 func parseFragmentName(lexer *Lexer) (*Name, error) {
-    fmt.Printf("\033[31m[INTO] func parseFragmentName  \033[0m\n")
-
     var name *Name
     var err   error
     shouldNotBe := map[string]bool{tokenNameMap[TOKEN_ON]: true}
@@ -546,8 +567,6 @@ func parseFragmentName(lexer *Lexer) (*Name, error) {
 }
 
 func parseTypeCondition(lexer *Lexer) (*Name, error) {
-    fmt.Printf("\033[31m[INTO] func parseTypeCondition  \033[0m\n")
-
     var name *Name 
     var err   error
 
@@ -573,8 +592,6 @@ func parseTypeCondition(lexer *Lexer) (*Name, error) {
  *
  */
 func parseValue(lexer *Lexer) (Value, error) {
-    fmt.Printf("\033[31m[INTO] func parseValue  \033[0m\n")
-
     var value Value
     var err error
     token := lexer.LookAhead()
@@ -635,32 +652,27 @@ func parseValue(lexer *Lexer) (Value, error) {
 }
 
 func parseBooleanValue(lexer *Lexer) (BooleanValue, error) {
-    fmt.Printf("\033[31m[INTO] func parseBooleanValue  \033[0m\n")
-
     if lexer.LookAhead() == TOKEN_TRUE {
         lexer.NextTokenIs(TOKEN_TRUE)
-        return BooleanValue{lexer.GetLineNum(), true}, nil
+        return BooleanValue{lexer.GetLineNum(), lexer.GetLocation(), true}, nil
     }
     lexer.NextTokenIs(TOKEN_FALSE)
-    return BooleanValue{lexer.GetLineNum(), false}, nil
+    return BooleanValue{lexer.GetLineNum(), lexer.GetLocation(), false}, nil
 }
 
 func parseNullValue(lexer *Lexer) (NullValue, error) {
-    fmt.Printf("\033[31m[INTO] func parseNullValue  \033[0m\n")
-
     lexer.NextTokenIs(TOKEN_NULL)
-    return NullValue{lexer.GetLineNum(), nil}, nil
+    return NullValue{lexer.GetLineNum(), lexer.GetLocation(), nil}, nil
 }
 
 func parseEnumValue(lexer *Lexer) (EnumValue, error) {
-    fmt.Printf("\033[31m[INTO] func parseBooleanValue  \033[0m\n")
-
     var enumValue EnumValue
     var err       error
     shouldNotBe := map[string]bool{tokenNameMap[TOKEN_TRUE]: true, tokenNameMap[TOKEN_FALSE]: true, tokenNameMap[TOKEN_NULL]: true}
 
     // LineNum
     enumValue.LineNum = lexer.GetLineNum()
+    enumValue.Location = lexer.GetNextTokenLocation()
     // Name
     if enumValue.Value, err = parseName(lexer); err != nil {
         return enumValue, err
@@ -673,10 +685,11 @@ func parseEnumValue(lexer *Lexer) (EnumValue, error) {
 }
 
 func parseListValue(lexer *Lexer) (ListValue, error) {
-    fmt.Printf("\033[31m[INTO] func parseListValue  \033[0m\n")
-
     var listValue ListValue
 
+    // LineNum
+    listValue.LineNum = lexer.GetLineNum()
+    listValue.Location = lexer.GetNextTokenLocation()
     // "["
     lexer.NextTokenIs(TOKEN_LEFT_BRACKET)
     // Value+
@@ -697,10 +710,11 @@ func parseListValue(lexer *Lexer) (ListValue, error) {
 }
 
 func parseObjectValue(lexer *Lexer) (ObjectValue, error) {
-    fmt.Printf("\033[31m[INTO] func parseObjectValue  \033[0m\n")
-
     var objectValue ObjectValue
 
+    // LineNum
+    objectValue.LineNum = lexer.GetLineNum()
+    objectValue.Location = lexer.GetNextTokenLocation()
     // "{"
     lexer.NextTokenIs(TOKEN_LEFT_BRACE)
     // ObjectField+
@@ -721,11 +735,12 @@ func parseObjectValue(lexer *Lexer) (ObjectValue, error) {
 }
 
 func parseObjectField(lexer *Lexer) (*ObjectField, error) {
-    fmt.Printf("\033[31m[INTO] func parseObjectField  \033[0m\n")
-
     var objectField ObjectField
     var err         error
 
+    // LineNum
+    objectField.LineNum = lexer.GetLineNum()
+    objectField.Location = lexer.GetNextTokenLocation()
     // Name
     if objectField.Name, err = parseName(lexer); err != nil {
         return nil, err
@@ -749,8 +764,6 @@ func parseObjectField(lexer *Lexer) (*ObjectField, error) {
  *
  */
 func parseVariableDefinitions(lexer *Lexer) ([]*VariableDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseVariableDefinitions  \033[0m\n")
-
     var VariableDefinitions []*VariableDefinition
 
     // "("
@@ -773,13 +786,12 @@ func parseVariableDefinitions(lexer *Lexer) ([]*VariableDefinition, error) {
 }
 
 func parseVariableDefinition(lexer *Lexer) (*VariableDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseVariableDefinition  \033[0m\n")
-
     var variableDefinition VariableDefinition
     var err                error
 
     // LineNum
     variableDefinition.LineNum = lexer.GetLineNum()
+    variableDefinition.Location = lexer.GetNextTokenLocation()
     // Variable
     if variableDefinition.Variable, err = parseVariable(lexer); err != nil {
         return nil, err
@@ -800,18 +812,22 @@ func parseVariableDefinition(lexer *Lexer) (*VariableDefinition, error) {
 }
 
 func parseVariable(lexer *Lexer) (Variable, error) {
-    fmt.Printf("\033[31m[INTO] func parseVariable  \033[0m\n")
+    var name *Name
+    var err   error
 
     // "$"
     lexer.NextTokenIs(TOKEN_VAR_PREFIX)
-    
+    location := lexer.GetLocation()
     // Name    
-    return parseName(lexer)
+    if name, err = parseName(lexer); err != nil {
+        return nil, err
+    }
+    // variable starts at "$"
+    name.Location = location
+    return name, nil
 }
 
 func parseDefaultValue(lexer *Lexer) (Value, error) {
-    fmt.Printf("\033[31m[INTO] func parseDefaultValue  \033[0m\n")
-
     var value Value 
     var err   error
 
@@ -834,21 +850,23 @@ func parseDefaultValue(lexer *Lexer) (Value, error) {
  *
  */
 func parseType(lexer *Lexer) (Type, error) {
-    fmt.Printf("\033[31m[INTO] func parseType  \033[0m\n")
-
     var typeRet Type
     var err     error
 
     // NamedType & ListType
-    switch lexer.LookAhead() {
-    case TOKEN_IDENTIFIER:   // NamedType
+    tokenType := lexer.LookAhead()
+    switch {
+    case isNameToken(tokenType):          // NamedType
         if typeRet, err = parseNamedType(lexer); err != nil {
             return nil, err
         }
-    case TOKEN_LEFT_BRACKET: // ListType, start with "["
+    case tokenType == TOKEN_LEFT_BRACKET: // ListType, start with "["
         if typeRet, err = parseListType(lexer); err != nil {
             return nil, err
         }
+    default:
+        token := lexer.nextToken
+        lexer.syntaxError(lexer.GetNextTokenLocation(), fmt.Sprintf("unexpected '%s', expected Type.", token), "Type", token)
     }
     // NonNullType
     if lexer.LookAhead() == TOKEN_NOT_NULL {
@@ -860,7 +878,10 @@ func parseType(lexer *Lexer) (Type, error) {
 }
 
 func parseNamedType(lexer *Lexer) (*NamedType, error) {
-    lineNum, _, token := lexer.GetNextToken()
+    lineNum, tokenType, token := lexer.GetNextToken()
+    if !isNameToken(tokenType) {
+        lexer.syntaxError(lexer.GetLocation(), fmt.Sprintf("unexpected '%s', expected Name.", token), "Name", token)
+    }
     for _, b := range []rune(token) {
         if (b == '_' || 
             b >= 'a' && b <= 'z' ||
@@ -872,38 +893,46 @@ func parseNamedType(lexer *Lexer) (*NamedType, error) {
             return nil, errors.New(err)
         }
     }
-    return &NamedType{lineNum, token}, nil
+    return &NamedType{lineNum, lexer.GetLocation(), token}, nil
 }  
 
 func parseListType(lexer *Lexer) (ListType, error) {
-    fmt.Printf("\033[31m[INTO] func parseListType  \033[0m\n")
-
     var listType ListType
 
+    // LineNum
+    listType.LineNum = lexer.GetLineNum()
+    listType.Location = lexer.GetNextTokenLocation()
     // "["
     lexer.NextTokenIs(TOKEN_LEFT_BRACKET) 
     // Type
-    for {
-        if lexer.LookAhead() == TOKEN_RIGHT_BRACKET {
-            break
-        } 
-        var typeRet Type
-        var err     error
-        if typeRet, err = parseType(lexer); err != nil {
-            return listType, err
-        }
-        listType.Type = append(listType.Type, typeRet)
+    var typeRet Type
+    var err     error
+    if typeRet, err = parseType(lexer); err != nil {
+        return listType, err
     }
+    listType.Type = append(listType.Type, typeRet)
     // "]"
     lexer.NextTokenIs(TOKEN_RIGHT_BRACKET)
     return listType, nil
 }
 
+// get location of a Type expression
+func getTypeLocation(t Type) Location {
+    switch t := t.(type) {
+    case *NamedType:
+        return t.Location
+    case ListType:
+        return t.Location
+    case NonNullType:
+        return t.Location
+    }
+    return Location{}
+}
+
 func parseNonNullType(lexer *Lexer, previousType Type) (NonNullType, error) {
-    fmt.Printf("\033[31m[INTO] func parseNonNullType  \033[0m\n")
     // "!"
     lexer.NextTokenIs(TOKEN_NOT_NULL)
-    return NonNullType{lexer.GetLineNum(), previousType}, nil
+    return NonNullType{lexer.GetLineNum(), getTypeLocation(previousType), previousType}, nil
 }
 
 
@@ -914,8 +943,6 @@ func parseNonNullType(lexer *Lexer, previousType Type) (NonNullType, error) {
  *
  */
 func parseDirectives(lexer *Lexer) ([]*Directive, error) {
-    fmt.Printf("\033[31m[INTO] func parseDirectives  \033[0m\n")
-
     var directives []*Directive
     
     // Directive+
@@ -931,13 +958,12 @@ func parseDirectives(lexer *Lexer) ([]*Directive, error) {
 }
 
 func parseDirective(lexer *Lexer) (*Directive, error) {
-    fmt.Printf("\033[31m[INTO] func parseDirective  \033[0m\n")
-
     var directive Directive
     var err       error
 
     // LineNum
     directive.LineNum = lexer.GetLineNum()
+    directive.Location = lexer.GetNextTokenLocation()
     // "@"
     lexer.NextTokenIs(TOKEN_AT)
     // Name
@@ -960,8 +986,6 @@ func parseDirective(lexer *Lexer) (*Directive, error) {
  *
  */
 func parseTypeSystemDefinition(lexer *Lexer) (Definition, error) {
-    fmt.Printf("\033[31m[INTO] func parseTypeSystemDefinition  \033[0m\n")
-
     var description StringValue 
 
     // Description
@@ -1094,13 +1118,12 @@ var parseDescription = parseStringValue
  *
  */
 func parseSchemaDefinition(lexer *Lexer) (*SchemaDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseSchemaDefinition  \033[0m\n")
-
     var schemaDefinition SchemaDefinition
     var err              error
 
     // LineNum
     schemaDefinition.LineNum = lexer.GetLineNum()
+    schemaDefinition.Location = lexer.GetNextTokenLocation()
     // "schema"
     lexer.NextTokenIs(TOKEN_SCHEMA)
     // Directives?
@@ -1126,13 +1149,12 @@ func parseSchemaDefinition(lexer *Lexer) (*SchemaDefinition, error) {
 }
 
 func parseSchemaExtension(lexer *Lexer) (*SchemaExtension, error) {
-    fmt.Printf("\033[31m[INTO] func parseSchemaExtension  \033[0m\n")
-
     var schemaExtension SchemaExtension
     var err             error
 
     // LineNum
     schemaExtension.LineNum = lexer.GetLineNum()
+    schemaExtension.Location = lexer.GetNextTokenLocation()
     // "extend" finished at parseTypeSystemExtension()
     // "schema"
     lexer.NextTokenIs(TOKEN_SCHEMA)
@@ -1167,13 +1189,12 @@ func parseSchemaExtension(lexer *Lexer) (*SchemaExtension, error) {
  *
  */
 func parseOperationTypeDefinition(lexer *Lexer) (*OperationTypeDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseOperationTypeDefinition  \033[0m\n")
-
     var operationTypeDefinition OperationTypeDefinition
     var err                     error
 
     // LineNum
     operationTypeDefinition.LineNum = lexer.GetLineNum()
+    operationTypeDefinition.Location = lexer.GetNextTokenLocation()
     // OperationType
     if operationTypeDefinition.OperationType, operationTypeDefinition.OperationTypeName = parseOperationType(lexer); err != nil {
         return nil, err
@@ -1203,13 +1224,12 @@ func parseOperationTypeDefinition(lexer *Lexer) (*OperationTypeDefinition, error
  *
  */
 func parseScalarTypeDefinition(lexer *Lexer) (*ScalarTypeDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseScalarTypeDefinition  \033[0m\n")
-
     var scalarTypeDefinition ScalarTypeDefinition
     var err                  error
 
     // LineNum
     scalarTypeDefinition.LineNum = lexer.GetLineNum()
+    scalarTypeDefinition.Location = lexer.GetNextTokenLocation()
     // Description? finished at parseTypeSystemDefinition()
     // "scalar"
     lexer.NextTokenIs(TOKEN_SCALAR)
@@ -1227,13 +1247,12 @@ func parseScalarTypeDefinition(lexer *Lexer) (*ScalarTypeDefinition, error) {
 }
 
 func parseScalarTypeExtension(lexer *Lexer) (*ScalarTypeExtension, error) {
-    fmt.Printf("\033[31m[INTO] func parseScalarTypeExtension  \033[0m\n")
-
     var scalarTypeExtension ScalarTypeExtension
     var err                  error
 
     // LineNum
     scalarTypeExtension.LineNum = lexer.GetLineNum()
+    scalarTypeExtension.Location = lexer.GetNextTokenLocation()
     // "extend" finished at parseTypeSystemExtension()
     // "scalar"
     lexer.NextTokenIs(TOKEN_SCALAR)
@@ -1257,13 +1276,12 @@ func parseScalarTypeExtension(lexer *Lexer) (*ScalarTypeExtension, error) {
  *
  */
 func parseObjectTypeDefinition(lexer *Lexer) (*ObjectTypeDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseObjectTypeDefinition  \033[0m\n")
-
     var objectTypeDefinition ObjectTypeDefinition
     var err                  error
 
     // LineNum
     objectTypeDefinition.LineNum = lexer.GetLineNum()
+    objectTypeDefinition.Location = lexer.GetNextTokenLocation()
     // Description? finished at parseTypeSystemDefinition()
     // "type"
     lexer.NextTokenIs(TOKEN_TYPE)
@@ -1293,13 +1311,12 @@ func parseObjectTypeDefinition(lexer *Lexer) (*ObjectTypeDefinition, error) {
 }
 
 func parseObjectTypeExtension(lexer *Lexer) (*ObjectTypeExtension, error) {
-    fmt.Printf("\033[31m[INTO] func parseObjectTypeDefinition  \033[0m\n")
-
     var objectTypeExtension ObjectTypeExtension
     var err                  error
 
     // LineNum
     objectTypeExtension.LineNum = lexer.GetLineNum()
+    objectTypeExtension.Location = lexer.GetNextTokenLocation()
     // "extend" finished at parseTypeSystemExtension()
     // "type"
     lexer.NextTokenIs(TOKEN_TYPE)
@@ -1334,12 +1351,11 @@ func parseObjectTypeExtension(lexer *Lexer) (*ObjectTypeExtension, error) {
  *
  */
 func parseImplementsInterfaces(lexer *Lexer) (*ImplementsInterfaces, error) {
-    fmt.Printf("\033[31m[INTO] func parseImplementsInterfaces  \033[0m\n")
-
     var implementsInterfaces ImplementsInterfaces
 
     // LineNum
     implementsInterfaces.LineNum = lexer.GetLineNum()
+    implementsInterfaces.Location = lexer.GetNextTokenLocation()
     // "implements"
     lexer.NextTokenIs(TOKEN_IMPLEMENTS)
     // ImplementsInterfaces
@@ -1372,13 +1388,12 @@ func parseImplementsInterfaces(lexer *Lexer) (*ImplementsInterfaces, error) {
  *
  */
 func parseInterfaceTypeDefinition(lexer *Lexer) (*InterfaceTypeDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseInterfaceTypeDefinition  \033[0m\n")
-
     var interfaceTypeDefinition InterfaceTypeDefinition
     var err                     error
 
     // LineNum
     interfaceTypeDefinition.LineNum = lexer.GetLineNum()
+    interfaceTypeDefinition.Location = lexer.GetNextTokenLocation()
     // Description? finished at parseTypeSystemDefinition()
     // "interface"
     lexer.NextTokenIs(TOKEN_INTERFACE)
@@ -1402,13 +1417,12 @@ func parseInterfaceTypeDefinition(lexer *Lexer) (*InterfaceTypeDefinition, error
 }
 
 func parseInterfaceTypeExtension(lexer *Lexer) (*InterfaceTypeExtension, error) {
-    fmt.Printf("\033[31m[INTO] func parseInterfaceTypeExtension  \033[0m\n")
-
     var interfaceTypeExtension InterfaceTypeExtension
     var err                    error
 
     // LineNum
     interfaceTypeExtension.LineNum = lexer.GetLineNum()
+    interfaceTypeExtension.Location = lexer.GetNextTokenLocation()
     // "extend" finished at parseTypeSystemExtension()
     // "interface"
     lexer.NextTokenIs(TOKEN_INTERFACE)
@@ -1442,13 +1456,12 @@ func parseInterfaceTypeExtension(lexer *Lexer) (*InterfaceTypeExtension, error)
  */
 
 func parseUnionTypeDefinition(lexer *Lexer) (*UnionTypeDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseUnionTypeDefinition  \033[0m\n")
-
     var unionTypeDefinition UnionTypeDefinition
     var err                 error
 
     // LineNum
     unionTypeDefinition.LineNum = lexer.GetLineNum()
+    unionTypeDefinition.Location = lexer.GetNextTokenLocation()
     // Description? finished at parseTypeSystemDefinition()
     // "union"
     lexer.NextTokenIs(TOKEN_UNION)
@@ -1472,41 +1485,40 @@ func parseUnionTypeDefinition(lexer *Lexer) (*UnionTypeDefinition, error) {
 }
 
 func parseUnionMemberTypes(lexer *Lexer) (*UnionMemberTypes, error) {
-    fmt.Printf("\033[31m[INTO] func parseUnionMemberTypes  \033[0m\n")
-
     var unionMemberTypes UnionMemberTypes
 
     // LineNum
     unionMemberTypes.LineNum = lexer.GetLineNum()
+    unionMemberTypes.Location = lexer.GetNextTokenLocation()
     // "=" 
     lexer.NextTokenIs(TOKEN_EQUAL)
-    // UnionMemberTypes
+    // "|"?
+    if lexer.LookAhead() == TOKEN_VERTICAL_BAR {
+        lexer.NextTokenIs(TOKEN_VERTICAL_BAR)
+    }
+    // NamedType ("|" NamedType)*
     for {
         var namedType *NamedType
         var err        error
-        // "|"?
-        if lexer.LookAhead() == TOKEN_VERTICAL_BAR {
-            lexer.NextTokenIs(TOKEN_VERTICAL_BAR)
-        }
-        if lexer.LookAhead() != TOKEN_IDENTIFIER {
-            break
-        }
         if namedType, err = parseNamedType(lexer); err != nil {
             return nil, err
         }
         unionMemberTypes.NamedTypes = append(unionMemberTypes.NamedTypes, namedType)
+        if lexer.LookAhead() != TOKEN_VERTICAL_BAR {
+            break
+        }
+        lexer.NextTokenIs(TOKEN_VERTICAL_BAR)
     }
     return &unionMemberTypes, nil
 }
 
 func parseUnionTypeExtension(lexer *Lexer) (*UnionTypeExtension, error) {
-    fmt.Printf("\033[31m[INTO] func parseUnionTypeExtension  \033[0m\n")
-
     var unionTypeExtension UnionTypeExtension
     var err                error
 
     // LineNum
     unionTypeExtension.LineNum = lexer.GetLineNum()
+    unionTypeExtension.Location = lexer.GetNextTokenLocation()
     // "extend" finished at parseTypeSystemExtension()
     // "union"
     lexer.NextTokenIs(TOKEN_UNION)
@@ -1538,13 +1550,12 @@ func parseUnionTypeExtension(lexer *Lexer) (*UnionTypeExtension, error) {
  *
  */
 func parseEnumTypeDefinition(lexer *Lexer) (*EnumTypeDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseEnumTypeDefinition  \033[0m\n")
-
     var enumTypeDefinition EnumTypeDefinition
     var err                error
 
     // LineNum
     enumTypeDefinition.LineNum = lexer.GetLineNum()
+    enumTypeDefinition.Location = lexer.GetNextTokenLocation()
     // Description? finished at parseTypeSystemDefinition()
     // "enum"
     lexer.NextTokenIs(TOKEN_ENUM)
@@ -1568,8 +1579,6 @@ func parseEnumTypeDefinition(lexer *Lexer) (*EnumTypeDefinition, error) {
 }
 
 func parseEnumValuesDefinition(lexer *Lexer) ([]*EnumValueDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseEnumValuesDefinition  \033[0m\n")
-
     var enumValuesDefinition []*EnumValueDefinition
 
     // "{"
@@ -1589,13 +1598,12 @@ func parseEnumValuesDefinition(lexer *Lexer) ([]*EnumValueDefinition, error) {
 }
 
 func parseEnumValueDefinition(lexer *Lexer) (*EnumValueDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseEnumValueDefinition  \033[0m\n")
-
     var enumValueDefinition EnumValueDefinition
     var err                 error
 
     // LineNum
     enumValueDefinition.LineNum = lexer.GetLineNum()
+    enumValueDefinition.Location = lexer.GetNextTokenLocation()
     // Description?
     enumValueDefinition.Description, _ = parseDescription(lexer) // this error can ignore
     // EnumValue
@@ -1612,13 +1620,12 @@ func parseEnumValueDefinition(lexer *Lexer) (*EnumValueDefinition, error) {
 }
 
 func parseEnumTypeExtension(lexer *Lexer) (*EnumTypeExtension, error) {
-    fmt.Printf("\033[31m[INTO] func parseEnumTypeExtension  \033[0m\n")
-
     var enumTypeExtension EnumTypeExtension
     var err               error
 
     // LineNum
     enumTypeExtension.LineNum = lexer.GetLineNum()
+    enumTypeExtension.Location = lexer.GetNextTokenLocation()
     // "extend" finished at parseTypeSystemExtension()
     // "enum"
     lexer.NextTokenIs(TOKEN_ENUM)
@@ -1648,13 +1655,12 @@ func parseEnumTypeExtension(lexer *Lexer) (*EnumTypeExtension, error) {
  *
  */
 func parseInputObjectTypeDefinition(lexer *Lexer) (*InputObjectTypeDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseInputObjectTypeDefinition  \033[0m\n")
-
     var inputObjectTypeDefinition InputObjectTypeDefinition
     var err                       error
 
     // LineNum
     inputObjectTypeDefinition.LineNum = lexer.GetLineNum()
+    inputObjectTypeDefinition.Location = lexer.GetNextTokenLocation()
     // Description? finished at parseTypeSystemDefinition()
     // "input"
     lexer.NextTokenIs(TOKEN_INPUT)
@@ -1678,8 +1684,6 @@ func parseInputObjectTypeDefinition(lexer *Lexer) (*InputObjectTypeDefinition, e
 }
 
 func parseInputFieldsDefinition(lexer *Lexer) ([]*InputValueDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseInputFieldsDefinition  \033[0m\n")
-
     var inputFieldsDefinition []*InputValueDefinition
 
     // "{"
@@ -1699,13 +1703,12 @@ func parseInputFieldsDefinition(lexer *Lexer) ([]*InputValueDefinition, error) {
 }
 
 func parseInputObjectTypeExtension(lexer *Lexer) (*InputObjectTypeExtension, error) {
-    fmt.Printf("\033[31m[INTO] func parseInputObjectTypeExtension  \033[0m\n")
-
     var inputObjectTypeExtension InputObjectTypeExtension
     var err                      error
 
     // LineNum
     inputObjectTypeExtension.LineNum = lexer.GetLineNum()
+    inputObjectTypeExtension.Location = lexer.GetNextTokenLocation()
     // "extend" finished at parseTypeSystemExtension()
     // "input"
     lexer.NextTokenIs(TOKEN_INPUT)
@@ -1738,13 +1741,12 @@ func parseInputObjectTypeExtension(lexer *Lexer) (*InputObjectTypeExtension, err
  *
  */
 func parseDirectiveDefinition(lexer *Lexer) (*DirectiveDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseDirectiveDefinition  \033[0m\n")
-
     var directiveDefinition DirectiveDefinition
     var err                 error
 
     // LineNum
     directiveDefinition.LineNum = lexer.GetLineNum()
+    directiveDefinition.Location = lexer.GetNextTokenLocation()
     // Description? finished at parseTypeSystemDefinition()
     // "directive"
     lexer.NextTokenIs(TOKEN_DIRECTIVE)
@@ -1770,33 +1772,29 @@ func parseDirectiveDefinition(lexer *Lexer) (*DirectiveDefinition, error) {
 }
 
 func parseDirectiveLocations(lexer *Lexer) ([]string, error) {
-    fmt.Printf("\033[31m[INTO] func parseDirectiveLocations  \033[0m\n")
-
     var directiveLocations []string
 
-    // DirectiveLocations
+    // "|"?
+    if lexer.LookAhead() == TOKEN_VERTICAL_BAR {
+        lexer.NextTokenIs(TOKEN_VERTICAL_BAR)
+    }
+    // DirectiveLocation ("|" DirectiveLocation)*
     for {
         var directiveLocation string
         var err               error
-        // "|"?
-        if lexer.LookAhead() == TOKEN_VERTICAL_BAR {
-            lexer.NextTokenIs(TOKEN_VERTICAL_BAR)
-        }
-        // not TOKEN_IDENTIFIER, finish
-        if lexer.LookAhead() != TOKEN_IDENTIFIER {
-            break
-        }
         if directiveLocation, err = parseDirectiveLocation(lexer); err != nil {
             return directiveLocations, err
         }
         directiveLocations = append(directiveLocations, directiveLocation)
+        if lexer.LookAhead() != TOKEN_VERTICAL_BAR {
+            break
+        }
+        lexer.NextTokenIs(TOKEN_VERTICAL_BAR)
     }
     return directiveLocations, nil
 }
 
 func parseDirectiveLocation(lexer *Lexer) (string, error) {
-    fmt.Printf("\033[31m[INTO] func parseDirectiveLocation  \033[0m\n")
-
     executableDirectiveLocation := map[string]bool{
         "QUERY": true,
         "MUTATION": true,
@@ -1836,8 +1834,6 @@ func parseDirectiveLocation(lexer *Lexer) (string, error) {
  *
  */
 func parseFieldsDefinition(lexer *Lexer) ([]*FieldDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseFieldsDefinition  \033[0m\n")
-
     var fieldsDefinition []*FieldDefinition
 
     // "{"
@@ -1857,13 +1853,12 @@ func parseFieldsDefinition(lexer *Lexer) ([]*FieldDefinition, error) {
 }
 
 func parseFieldDefinition(lexer *Lexer) (*FieldDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseFieldDefinition  \033[0m\n")
-
     var fieldDefinition FieldDefinition
     var err             error
 
     // LineNum
     fieldDefinition.LineNum = lexer.GetLineNum()
+    fieldDefinition.Location = lexer.GetNextTokenLocation()
     // Description? 
     fieldDefinition.Description, _ = parseDescription(lexer)
     // Name
@@ -1899,8 +1894,6 @@ func parseFieldDefinition(lexer *Lexer) (*FieldDefinition, error) {
  *
  */
 func parseArgumentsDefinition(lexer *Lexer) (ArgumentsDefinition, error) {
-    fmt.Printf("\033[31m[INTO] func parseArgumentsDefinition  \033[0m\n")
-
     var argumentsDefinition ArgumentsDefinition
 
     // "("
@@ -1920,13 +1913,12 @@ func parseArgumentsDefinition(lexer *Lexer) (ArgumentsDefinition, error) {
 }
 
 func parseInputValueDefinition(lexer *Lexer) (*InputValueDefinition, error) {
-     fmt.Printf("\033[31m[INTO] func parseInputValueDefinition  \033[0m\n")
-
     var inputValueDefinition InputValueDefinition
     var err                  error
 
     // LineNum
     inputValueDefinition.LineNum = lexer.GetLineNum()
+    inputValueDefinition.Location = lexer.GetNextTokenLocation()
     // Description? 
     inputValueDefinition.Description, _ = parseDescription(lexer)
     // Name
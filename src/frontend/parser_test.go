@@ -0,0 +1,54 @@
+// parser_test.go
+
+package frontend
+
+import (
+    "testing"
+)
+
+/**
+ * Block string value
+ * raw is the content between """ and """, which was the StringValue before block strings were
+ * dedented, value is the StringValue after removing common indentation and blank lines.
+ */
+var blockStringTests = []struct {
+    name  string
+    raw   string
+    value string
+}{
+    {"single line", "hello", "hello"},
+    {"indented description", "\n    the user id\n  ", "the user id"},
+    {"common indentation", "\n    first\n      nested\n    last\n", "first\n  nested\nlast"},
+    {"first line keeps indentation", "  first\n    second", "  first\nsecond"},
+    {"blank lines inside", "\n  a\n\n  b\n", "a\n\nb"},
+    {"whitespace only lines", "\n  a\n   \n  b\n  \t\n", "a\n \nb"},
+    {"crlf line endings", "\r\n    a\r\n    b\r\n", "a\nb"},
+    {"cr line endings", "\r    a\r    b\r", "a\nb"},
+    {"empty", "", ""},
+}
+
+func TestGetBlockStringValue(t *testing.T) {
+    for _, test := range blockStringTests {
+        if value := getBlockStringValue(test.raw); value != test.value {
+            t.Errorf("%s: getBlockStringValue(%q) = %q, want %q", test.name, test.raw, value, test.value)
+        }
+    }
+}
+
+func TestCompileBlockString(t *testing.T) {
+    for _, test := range blockStringTests {
+        if test.raw == "" {
+            continue
+        }
+        document, err := Compile("{ f(s: \"\"\"" + test.raw + "\"\"\") }")
+        if err != nil {
+            t.Errorf("%s: Compile() error: %v", test.name, err)
+            continue
+        }
+        field := document.Definitions[0].(*OperationDefinition).SelectionSet.Selections[0].(*Field)
+        value := field.Arguments[0].Value.(StringValue).Value
+        if value != test.value {
+            t.Errorf("%s: block string %q parsed as %q, want %q", test.name, test.raw, value, test.value)
+        }
+    }
+}
@@ -0,0 +1,388 @@
+// printer.go
+
+package frontend
+
+import (
+    "strconv"
+    "strings"
+)
+
+/**
+ * Printer
+ * print AST node (Document, Definition, Selection, Value, Type etc.) back to GraphQL source.
+ * Print() uses pretty mode, which puts every selection, field and definition on it's own line
+ * and indents nested blocks by 2 spaces. PrintCompact() prints the node in one line and drops
+ * optional whitespace, e.g. persisted query normalization:
+ *
+ *     Print(document)         query Q($id: ID!) {
+ *                               user(id: $id) {
+ *                                 name
+ *                               }
+ *                             }
+ *     PrintCompact(document)  query Q($id:ID!){user(id:$id){name}}
+ *
+ * StringValue is printed as it is in source since lexer keeps escape sequences, values with line
+ * terminator or quote are printed as block string. nil or unknown node is printed as "".
+ */
+func Print(node interface{}) string {
+    printer := &printer{compact: false}
+    return printer.print(node)
+}
+
+func PrintCompact(node interface{}) string {
+    printer := &printer{compact: true}
+    return printer.print(node)
+}
+
+type printer struct {
+    compact bool
+}
+
+func (printer *printer) print(node interface{}) string {
+    switch n := node.(type) {
+    case *Document:
+        return printer.printDocument(n)
+    // executable definitions
+    case *OperationDefinition:
+        return printer.printOperationDefinition(n)
+    case *FragmentDefinition:
+        return "fragment " + n.Name.Value + " on " + n.TypeCondition.Value + printer.printDirectives(n.Directives) + printer.space() + printer.print(n.SelectionSet)
+    case *VariableDefinition:
+        printed := "$" + n.Variable.Value + ":" + printer.space() + printer.print(n.Type)
+        if n.DefaultValue != nil {
+            printed += printer.space() + "=" + printer.space() + printer.print(n.DefaultValue)
+        }
+        return printed
+    case *SelectionSet:
+        var selections []string
+        for _, selection := range n.Selections {
+            selections = append(selections, printer.print(selection))
+        }
+        return printer.printBlock(selections)
+    case *Field:
+        var printed string
+        if n.Alias != nil {
+            printed = n.Alias.Name.Value + ":" + printer.space()
+        }
+        printed += n.Name.Value + printer.printArguments(n.Arguments) + printer.printDirectives(n.Directives)
+        if n.SelectionSet != nil {
+            printed += printer.space() + printer.print(n.SelectionSet)
+        }
+        return printed
+    case *FragmentSpread:
+        return "..." + n.Name.Value + printer.printDirectives(n.Directives)
+    case *InlineFragment:
+        printed := "..."
+        if n.TypeCondition != nil {
+            printed += printer.space() + "on " + n.TypeCondition.Value
+        }
+        return printed + printer.printDirectives(n.Directives) + printer.space() + printer.print(n.SelectionSet)
+    case *Alias:
+        return n.Name.Value
+    case *TypeCondition:
+        return "on " + n.NamedType.Value
+    case *Argument:
+        return n.Name.Value + ":" + printer.space() + printer.print(n.Value)
+    case *Directive:
+        return "@" + n.Name.Value + printer.printArguments(n.Arguments)
+    case *Name:
+        return n.Value
+    // values
+    case Variable:
+        return "$" + n.Value
+    case IntValue:
+        return strconv.Itoa(n.Value)
+    case FloatValue:
+        return printFloat(n.Value)
+    case StringValue:
+        return printStringValue(n.Value)
+    case BooleanValue:
+        return strconv.FormatBool(n.Value)
+    case NullValue:
+        return "null"
+    case EnumValue:
+        return n.Value.Value
+    case ListValue:
+        var items []string
+        for _, item := range n.Value {
+            items = append(items, printer.print(item))
+        }
+        return "[" + strings.Join(items, "," + printer.space()) + "]"
+    case ObjectValue:
+        var fields []string
+        for _, field := range n.Value {
+            fields = append(fields, printer.print(field))
+        }
+        return "{" + strings.Join(fields, "," + printer.space()) + "}"
+    case *ObjectField:
+        return n.Name.Value + ":" + printer.space() + printer.print(n.Value)
+    // types
+    case *NamedType:
+        return n.Value
+    case ListType:
+        if len(n.Type) == 0 {
+            return "[]"
+        }
+        return "[" + printer.print(n.Type[0]) + "]"
+    case *ListType:
+        return printer.print(*n)
+    case NonNullType:
+        return printer.print(n.Type) + "!"
+    case *NonNullType:
+        return printer.print(*n)
+    // type system definitions and extensions
+    case *SchemaDefinition:
+        return "schema" + printer.printDirectives(n.Directives) + printer.space() + printer.printOperationTypeDefinitions(n.OperationTypeDefinitions)
+    case *SchemaExtension:
+        printed := "extend schema" + printer.printDirectives(n.Directives)
+        if len(n.OperationTypeDefinitions) > 0 {
+            printed += printer.space() + printer.printOperationTypeDefinitions(n.OperationTypeDefinitions)
+        }
+        return printed
+    case *OperationTypeDefinition:
+        return n.OperationTypeName + ":" + printer.space() + n.NamedType.Value
+    case *ScalarTypeDefinition:
+        return printer.printDescription(n.Description) + "scalar " + n.Name.Value + printer.printDirectives(n.Directives)
+    case *ScalarTypeExtension:
+        return "extend scalar " + n.Name.Value + printer.printDirectives(n.Directives)
+    case *ObjectTypeDefinition:
+        return printer.printDescription(n.Description) + "type " + n.Name.Value + printer.print(n.ImplementsInterfaces) + printer.printDirectives(n.Directives) + printer.printFieldsDefinition(n.FieldsDefinition)
+    case *ObjectTypeExtension:
+        return "extend type " + n.Name.Value + printer.print(n.ImplementsInterfaces) + printer.printDirectives(n.Directives) + printer.printFieldsDefinition(n.FieldsDefinition)
+    case *ImplementsInterfaces:
+        if n == nil || len(n.NamedTypes) == 0 {
+            return ""
+        }
+        return " implements " + printer.printNamedTypes(n.NamedTypes, "&")
+    case *InterfaceTypeDefinition:
+        return printer.printDescription(n.Description) + "interface " + n.Name.Value + printer.printDirectives(n.Directives) + printer.printFieldsDefinition(n.FieldsDefinition)
+    case *InterfaceTypeExtension:
+        return "extend interface " + n.Name.Value + printer.printDirectives(n.Directives) + printer.printFieldsDefinition(n.FieldsDefinition)
+    case *UnionTypeDefinition:
+        return printer.printDescription(n.Description) + "union " + n.Name.Value + printer.printDirectives(n.Directives) + printer.print(n.UnionMemberTypes)
+    case *UnionTypeExtension:
+        return "extend union " + n.Name.Value + printer.printDirectives(n.Directives) + printer.print(n.UnionMemberTypes)
+    case *UnionMemberTypes:
+        if n == nil || len(n.NamedTypes) == 0 {
+            return ""
+        }
+        return printer.space() + "=" + printer.space() + printer.printNamedTypes(n.NamedTypes, "|")
+    case *EnumTypeDefinition:
+        return printer.printDescription(n.Description) + "enum " + n.Name.Value + printer.printDirectives(n.Directives) + printer.printEnumValuesDefinition(n.EnumValuesDefinition)
+    case *EnumTypeExtension:
+        return "extend enum " + n.Name.Value + printer.printDirectives(n.Directives) + printer.printEnumValuesDefinition(n.EnumValuesDefinition)
+    case *EnumValueDefinition:
+        return printer.printDescription(n.Description) + n.EnumValue.Value.Value + printer.printDirectives(n.Directives)
+    case *InputObjectTypeDefinition:
+        return printer.printDescription(n.Description) + "input " + n.Name.Value + printer.printDirectives(n.Directives) + printer.printInputFieldsDefinition(n.InputFieldsDefinition)
+    case *InputObjectTypeExtension:
+        return "extend input " + n.Name.Value + printer.printDirectives(n.Directives) + printer.printInputFieldsDefinition(n.InputFieldsDefinition)
+    case *DirectiveDefinition:
+        return printer.printDescription(n.Description) + "directive @" + n.Name.Value + printer.printArgumentsDefinition(n.ArgumentsDefinition) + " on " + strings.Join(n.DirectiveLocations, printer.space() + "|" + printer.space())
+    case DirectiveDefinition:
+        return printer.print(&n)
+    case *FieldDefinition:
+        return printer.printDescription(n.Description) + n.Name.Value + printer.printArgumentsDefinition(n.ArgumentsDefinition) + ":" + printer.space() + printer.print(n.Type) + printer.printDirectives(n.Directives)
+    case *InputValueDefinition:
+        printed := printer.printDescription(n.Description) + n.Name.Value + ":" + printer.space() + printer.print(n.Type)
+        if n.DefaultValue != nil {
+            printed += printer.space() + "=" + printer.space() + printer.print(n.DefaultValue)
+        }
+        return printed + printer.printDirectives(n.Directives)
+    }
+    return ""
+}
+
+// definitions are separated by blank line in pretty mode
+func (printer *printer) printDocument(document *Document) string {
+    var definitions []string
+    for _, definition := range document.Definitions {
+        definitions = append(definitions, printer.print(definition))
+    }
+    if printer.compact {
+        return strings.Join(definitions, " ")
+    }
+    return strings.Join(definitions, "\n\n")
+}
+
+// anonymous query without variables and directives is printed in shorthand form
+func (printer *printer) printOperationDefinition(operationDefinition *OperationDefinition) string {
+    operationTypeName := operationDefinition.OperationTypeName
+    if operationTypeName == "" {
+        operationTypeName = OperationTypeQueryName
+    }
+    isShorthand := operationTypeName == OperationTypeQueryName && operationDefinition.Name == nil && len(operationDefinition.VariableDefinitions) == 0 && len(operationDefinition.Directives) == 0
+    if isShorthand {
+        return printer.print(operationDefinition.SelectionSet)
+    }
+    printed := operationTypeName
+    if operationDefinition.Name != nil {
+        printed += " " + operationDefinition.Name.Value
+    }
+    if len(operationDefinition.VariableDefinitions) > 0 {
+        var variableDefinitions []string
+        for _, variableDefinition := range operationDefinition.VariableDefinitions {
+            variableDefinitions = append(variableDefinitions, printer.print(variableDefinition))
+        }
+        printed += "(" + strings.Join(variableDefinitions, "," + printer.space()) + ")"
+    }
+    return printed + printer.printDirectives(operationDefinition.Directives) + printer.space() + printer.print(operationDefinition.SelectionSet)
+}
+
+func (printer *printer) printArguments(arguments []*Argument) string {
+    if len(arguments) == 0 {
+        return ""
+    }
+    var printed []string
+    for _, argument := range arguments {
+        printed = append(printed, printer.print(argument))
+    }
+    return "(" + strings.Join(printed, "," + printer.space()) + ")"
+}
+
+func (printer *printer) printDirectives(directives []*Directive) string {
+    if len(directives) == 0 {
+        return ""
+    }
+    var printed []string
+    for _, directive := range directives {
+        printed = append(printed, printer.print(directive))
+    }
+    if printer.compact {
+        return strings.Join(printed, "")
+    }
+    return " " + strings.Join(printed, " ")
+}
+
+func (printer *printer) printOperationTypeDefinitions(operationTypeDefinitions []*OperationTypeDefinition) string {
+    var printed []string
+    for _, operationTypeDefinition := range operationTypeDefinitions {
+        printed = append(printed, printer.print(operationTypeDefinition))
+    }
+    return printer.printBlock(printed)
+}
+
+// print "A & B" of ImplementsInterfaces, "A | B" of UnionMemberTypes
+func (printer *printer) printNamedTypes(namedTypes []*NamedType, separator string) string {
+    var printed []string
+    for _, namedType := range namedTypes {
+        printed = append(printed, namedType.Value)
+    }
+    return strings.Join(printed, printer.space() + separator + printer.space())
+}
+
+func (printer *printer) printFieldsDefinition(fieldDefinitions []*FieldDefinition) string {
+    if len(fieldDefinitions) == 0 {
+        return ""
+    }
+    var printed []string
+    for _, fieldDefinition := range fieldDefinitions {
+        printed = append(printed, printer.print(fieldDefinition))
+    }
+    return printer.space() + printer.printBlock(printed)
+}
+
+func (printer *printer) printInputFieldsDefinition(inputValueDefinitions []*InputValueDefinition) string {
+    if len(inputValueDefinitions) == 0 {
+        return ""
+    }
+    var printed []string
+    for _, inputValueDefinition := range inputValueDefinitions {
+        printed = append(printed, printer.print(inputValueDefinition))
+    }
+    return printer.space() + printer.printBlock(printed)
+}
+
+func (printer *printer) printEnumValuesDefinition(enumValueDefinitions []*EnumValueDefinition) string {
+    if len(enumValueDefinitions) == 0 {
+        return ""
+    }
+    var printed []string
+    for _, enumValueDefinition := range enumValueDefinitions {
+        printed = append(printed, printer.print(enumValueDefinition))
+    }
+    return printer.space() + printer.printBlock(printed)
+}
+
+// print arguments in one line, or one argument per line in pretty mode if any argument has description
+func (printer *printer) printArgumentsDefinition(inputValueDefinitions []*InputValueDefinition) string {
+    if len(inputValueDefinitions) == 0 {
+        return ""
+    }
+    var printed []string
+    var hasDescription bool
+    for _, inputValueDefinition := range inputValueDefinitions {
+        printed = append(printed, printer.print(inputValueDefinition))
+        hasDescription = hasDescription || inputValueDefinition.Description.Value != ""
+    }
+    if hasDescription && !printer.compact {
+        return "(\n" + indent(strings.Join(printed, "\n")) + "\n)"
+    }
+    return "(" + strings.Join(printed, "," + printer.space()) + ")"
+}
+
+// print description as block string before definition
+func (printer *printer) printDescription(description StringValue) string {
+    if description.Value == "" {
+        return ""
+    }
+    if printer.compact {
+        return printBlockString(description.Value)
+    }
+    return printBlockString(description.Value) + "\n"
+}
+
+// print items in braces, one item per line in pretty mode
+func (printer *printer) printBlock(items []string) string {
+    if printer.compact {
+        return "{" + strings.Join(items, " ") + "}"
+    }
+    if len(items) == 0 {
+        return "{}"
+    }
+    return "{\n" + indent(strings.Join(items, "\n")) + "\n}"
+}
+
+// optional whitespace, dropped in compact mode
+func (printer *printer) space() string {
+    if printer.compact {
+        return ""
+    }
+    return " "
+}
+
+// indent every non-empty line by 2 spaces
+func indent(printed string) string {
+    lines := strings.Split(printed, "\n")
+    for i, line := range lines {
+        if line != "" {
+            lines[i] = "  " + line
+        }
+    }
+    return strings.Join(lines, "\n")
+}
+
+// float keeps "." or exponent, so it is not printed as IntValue
+func printFloat(value float64) string {
+    printed := strconv.FormatFloat(value, 'g', -1, 64)
+    if !strings.ContainsAny(printed, ".eEnN") {
+        printed += ".0"
+    }
+    return printed
+}
+
+func printStringValue(value string) string {
+    if strings.ContainsAny(value, "\"\r\n") {
+        return printBlockString(value)
+    }
+    return "\"" + value + "\""
+}
+
+// print block string, multi-line form if value has line terminator or ends with quote
+func printBlockString(value string) string {
+    value = strings.ReplaceAll(value, "\"\"\"", "\\\"\"\"")
+    if !strings.ContainsAny(value, "\r\n") && !strings.HasSuffix(value, "\"") && !strings.HasSuffix(value, "\\") {
+        return "\"\"\"" + value + "\"\"\""
+    }
+    return "\"\"\"\n" + value + "\n\"\"\""
+}
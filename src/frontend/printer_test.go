@@ -0,0 +1,99 @@
+// printer_test.go
+
+package frontend
+
+import (
+    "testing"
+)
+
+/**
+ * Printer corpus
+ * pretty and compact are the expected Print() and PrintCompact() outputs, empty means the document
+ * is only checked for Print -> Compile round trip.
+ */
+var printerTests = []struct {
+    name    string
+    source  string
+    pretty  string
+    compact string
+}{
+    {
+        "operation",
+        "query Q($id: ID! = 1, $f: [Float!] = [1.5, -2]) @live { user(id: $id) { ...F ... on User @include(if: true) { name } ... { a: b } } }",
+        "query Q($id: ID! = 1, $f: [Float!] = [1.5, -2]) @live {\n  user(id: $id) {\n    ...F\n    ... on User @include(if: true) {\n      name\n    }\n    ... {\n      a: b\n    }\n  }\n}",
+        "query Q($id:ID!=1,$f:[Float!]=[1.5,-2])@live{user(id:$id){...F ...on User@include(if:true){name} ...{a:b}}}",
+    },
+    {
+        "fragment",
+        "fragment F on User { id friends(first: 10, after: null, order: {by: NAME, desc: false}) { name } }",
+        "fragment F on User {\n  id\n  friends(first: 10, after: null, order: {by: NAME, desc: false}) {\n    name\n  }\n}",
+        "fragment F on User{id friends(first:10,after:null,order:{by:NAME,desc:false}){name}}",
+    },
+    {
+        "strings",
+        "{ a(s: \"line\\nslash \\\\ tab\\t\", b: \"\"\"block with\n  lines\"\"\") }",
+        "{\n  a(s: \"line\\nslash \\\\ tab\\t\", b: \"\"\"\n  block with\n  lines\n  \"\"\")\n}",
+        "{a(s:\"line\\nslash \\\\ tab\\t\",b:\"\"\"\nblock with\nlines\n\"\"\")}",
+    },
+    {
+        "object type",
+        "\"The user\" type User implements Node & Named @key(fields: \"id\") { \"id\" id: ID! @deprecated(reason: \"x\") name(\"arg\" upper: Boolean = false @d): String }",
+        "\"\"\"The user\"\"\"\ntype User implements Node & Named @key(fields: \"id\") {\n  \"\"\"id\"\"\"\n  id: ID! @deprecated(reason: \"x\")\n  name(\n    \"\"\"arg\"\"\"\n    upper: Boolean = false @d\n  ): String\n}",
+        "\"\"\"The user\"\"\"type User implements Node&Named@key(fields:\"id\"){\"\"\"id\"\"\"id:ID!@deprecated(reason:\"x\") name(\"\"\"arg\"\"\"upper:Boolean=false@d):String}",
+    },
+    {
+        "type definitions",
+        "interface Node { id: ID! } union U = | A | B enum E { \"v\" A @d B } input I { a: Int = 1 b: [String!]! }",
+        "interface Node {\n  id: ID!\n}\n\nunion U = A | B\n\nenum E {\n  \"\"\"v\"\"\"\n  A @d\n  B\n}\n\ninput I {\n  a: Int = 1\n  b: [String!]!\n}",
+        "interface Node{id:ID!} union U=A|B enum E{\"\"\"v\"\"\"A@d B} input I{a:Int=1 b:[String!]!}",
+    },
+    {"schema", "schema @d { query: Query mutation: Mutation }", "", ""},
+    {"scalar and directive", "scalar Date @specifiedBy(url: \"u\") directive @d(a: Int) on FIELD | QUERY", "", ""},
+    {
+        "extensions",
+        "extend type User { age: Int } extend schema { subscription: S } extend union U = C extend enum E { C } extend input I { c: Int } extend scalar Date @d extend interface Node @d",
+        "", "",
+    },
+    {"mixed document", "mutation { like(id: 1) { count } } subscription S { liked } type Query { a: [[Int!]]! }", "", ""},
+}
+
+func TestPrint(t *testing.T) {
+    for _, test := range printerTests {
+        document, err := Compile(test.source)
+        if err != nil {
+            t.Errorf("%s: Compile() error: %v", test.name, err)
+            continue
+        }
+        if test.pretty != "" {
+            if printed := Print(document); printed != test.pretty {
+                t.Errorf("%s: Print() =\n%s\nwant:\n%s", test.name, printed, test.pretty)
+            }
+        }
+        if test.compact != "" {
+            if printed := PrintCompact(document); printed != test.compact {
+                t.Errorf("%s: PrintCompact() =\n%s\nwant:\n%s", test.name, printed, test.compact)
+            }
+        }
+    }
+}
+
+func TestPrintRoundTrip(t *testing.T) {
+    for _, test := range printerTests {
+        document, err := Compile(test.source)
+        if err != nil {
+            t.Errorf("%s: Compile() error: %v", test.name, err)
+            continue
+        }
+        printed := Print(document)
+        for _, output := range []string{printed, PrintCompact(document)} {
+            reparsed, err := Compile(output)
+            if err != nil {
+                t.Errorf("%s: Compile(%q) error: %v", test.name, output, err)
+                continue
+            }
+            if reprinted := Print(reparsed); reprinted != printed {
+                t.Errorf("%s: Print(Compile(%q)) =\n%s\nwant:\n%s", test.name, output, reprinted, printed)
+            }
+        }
+    }
+}
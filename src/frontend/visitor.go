@@ -0,0 +1,303 @@
+// visitor.go
+
+package frontend
+
+import (
+    "reflect"
+)
+
+/**
+ * Visitor
+ * walk AST in depth-first order, call Enter callback before the children of node are visited, and
+ * Leave callback after. Callbacks are keyed by node kind, which is the AST type name, e.g. "Field",
+ * "OperationDefinition", "IntValue", see GetNodeKind(). e.g. rename every field "user" to "viewer":
+ *
+ *     document = Visit(document, &Visitor{
+ *         Enter: map[string]VisitFunc{
+ *             "Field": func(cursor *Cursor) VisitAction {
+ *                 if field := cursor.Node().(*Field); field.Name.Value == "user" {
+ *                     field.Name = &Name{field.Name.LineNum, field.Name.Location, "viewer"}
+ *                 }
+ *                 return VisitContinue
+ *             },
+ *         },
+ *     }).(*Document)
+ *
+ * Children are the AST fields of node: Definitions, Selections, Arguments, Values, Types, Names etc.
+ * LineNum and Location are not visited, Description is only visited when it is not empty.
+ * Cursor.Replace() and Cursor.Delete() modify the parent of current node in place, the replaced node
+ * is walked instead of the original one when replaced in Enter callback.
+ */
+
+type VisitAction int
+
+const (
+    // continue walking
+    VisitContinue VisitAction = iota
+    // do not visit the children of current node, Leave callback of current node is skipped, only for Enter callback
+    VisitSkip
+    // stop walking
+    VisitBreak
+)
+
+type VisitFunc func(cursor *Cursor) VisitAction
+
+type Visitor struct {
+    Enter map[string]VisitFunc
+    Leave map[string]VisitFunc
+}
+
+/**
+ * Cursor
+ * position of the current node in walking, passed into VisitFunc.
+ * Path is the keys from root to current node, field name (string) or slice index (int),
+ * e.g. ["Definitions", 0, "SelectionSet", "Selections", 1]
+ */
+type Cursor struct {
+    node        interface{}
+    path      []interface{}
+    ancestors []interface{}
+    deleted     bool
+}
+
+func (cursor *Cursor) Node() interface{} {
+    return cursor.node
+}
+
+func (cursor *Cursor) Kind() string {
+    return GetNodeKind(cursor.node)
+}
+
+// field name or slice index of current node in parent, nil for root
+func (cursor *Cursor) Key() interface{} {
+    if len(cursor.path) == 0 {
+        return nil
+    }
+    return cursor.path[len(cursor.path)-1]
+}
+
+// parent node, nil for root
+func (cursor *Cursor) Parent() interface{} {
+    if len(cursor.ancestors) == 0 {
+        return nil
+    }
+    return cursor.ancestors[len(cursor.ancestors)-1]
+}
+
+func (cursor *Cursor) Path() []interface{} {
+    return append([]interface{}{}, cursor.path...)
+}
+
+// nodes from root to parent
+func (cursor *Cursor) Ancestors() []interface{} {
+    return append([]interface{}{}, cursor.ancestors...)
+}
+
+// replace current node, the node must be assignable to the parent field, e.g. Selection for Selections
+func (cursor *Cursor) Replace(node interface{}) {
+    cursor.node    = node
+    cursor.deleted = false
+}
+
+// delete current node from parent, slice item is removed, field is set to nil
+func (cursor *Cursor) Delete() {
+    cursor.deleted = true
+}
+
+/**
+ * Visit
+ * walk root node by visitor, return the root node, which is replaced or nil if the root node
+ * is replaced or deleted by callbacks. Nodes are modified in place.
+ */
+func Visit(root interface{}, visitor *Visitor) interface{} {
+    walker := &walker{visitor: visitor}
+    node, deleted := walker.walk(root, &Cursor{})
+    if deleted {
+        return nil
+    }
+    return node
+}
+
+/**
+ * Walk
+ * call walkFunc on every node before it's children, e.g. collect all fragment spreads:
+ *
+ *     Walk(document, func(cursor *Cursor) VisitAction {
+ *         if fragmentSpread, ok := cursor.Node().(*FragmentSpread); ok {
+ *             fragmentSpreads = append(fragmentSpreads, fragmentSpread)
+ *         }
+ *         return VisitContinue
+ *     })
+ */
+func Walk(root interface{}, walkFunc VisitFunc) {
+    walker := &walker{visitor: &Visitor{}, walkFunc: walkFunc}
+    walker.walk(root, &Cursor{})
+}
+
+// get node kind by AST type name, e.g. "Field" for *Field, "IntValue" for IntValue
+func GetNodeKind(node interface{}) string {
+    nodeType := reflect.TypeOf(node)
+    if nodeType == nil {
+        return ""
+    }
+    if nodeType.Kind() == reflect.Ptr && nodeType.Name() == "" {
+        return nodeType.Elem().Name()
+    }
+    return nodeType.Name()
+}
+
+type walker struct {
+    visitor   *Visitor
+    walkFunc   VisitFunc
+    stopped    bool
+}
+
+var locationType = reflect.TypeOf(Location{})
+
+// check struct field type is AST node or slice of AST nodes
+func isNodeType(fieldType reflect.Type) bool {
+    if fieldType.Kind() == reflect.Slice {
+        fieldType = fieldType.Elem()
+    }
+    if fieldType == locationType {
+        return false
+    }
+    if fieldType.Kind() == reflect.Ptr && fieldType.Name() == "" {
+        fieldType = fieldType.Elem()
+    }
+    switch fieldType.Kind() {
+    case reflect.Struct, reflect.Interface, reflect.Ptr:
+        return fieldType.PkgPath() == locationType.PkgPath()
+    }
+    return false
+}
+
+// call Enter or Leave callbacks of node
+func (walker *walker) call(callbacks map[string]VisitFunc, walkFunc VisitFunc, cursor *Cursor) VisitAction {
+    action := VisitContinue
+    if walkFunc != nil {
+        action = walkFunc(cursor)
+    }
+    if callback, ok := callbacks[cursor.Kind()]; ok && action == VisitContinue && !cursor.deleted {
+        action = callback(cursor)
+    }
+    if action == VisitBreak {
+        walker.stopped = true
+    }
+    return action
+}
+
+// walk node and it's children, return the node (may be replaced) and whether it is deleted
+func (walker *walker) walk(node interface{}, cursor *Cursor) (interface{}, bool) {
+    cursor.node    = node
+    cursor.deleted = false
+    if walker.call(walker.visitor.Enter, walker.walkFunc, cursor) == VisitSkip || cursor.deleted || walker.stopped {
+        return cursor.node, cursor.deleted
+    }
+    node = walker.walkChildren(cursor.node, cursor)
+    if walker.stopped {
+        return node, false
+    }
+    cursor.node    = node
+    cursor.deleted = false
+    walker.call(walker.visitor.Leave, nil, cursor)
+    return cursor.node, cursor.deleted
+}
+
+/**
+ * walk every AST field of node. Pointer nodes are modified in place, value nodes (e.g. ListValue)
+ * are walked on a copy, the copy is returned as the new node.
+ */
+func (walker *walker) walkChildren(node interface{}, cursor *Cursor) interface{} {
+    nodeValue := reflect.ValueOf(node)
+    if !nodeValue.IsValid() {
+        return node
+    }
+    var structValue reflect.Value
+    switch {
+    case nodeValue.Kind() == reflect.Ptr && nodeValue.Type().Name() == "" && nodeValue.Elem().Kind() == reflect.Struct:
+        structValue = nodeValue.Elem()
+    case nodeValue.Kind() == reflect.Struct:
+        structValue = reflect.New(nodeValue.Type()).Elem()
+        structValue.Set(nodeValue)
+    default:
+        return node
+    }
+
+    ancestors := cursor.ancestors
+    path      := cursor.path
+    for i := 0; i < structValue.NumField() && !walker.stopped; i++ {
+        field     := structValue.Field(i)
+        fieldName := structValue.Type().Field(i).Name
+        if !isNodeType(field.Type()) {
+            continue
+        }
+        // empty description is absent
+        if description, ok := field.Interface().(StringValue); ok && fieldName == "Description" && description.Value == "" {
+            continue
+        }
+        childCursor := &Cursor{ancestors: append(append([]interface{}{}, ancestors...), node), path: append(append([]interface{}{}, path...), fieldName)}
+        if field.Kind() != reflect.Slice {
+            if isNilValue(field) {
+                continue
+            }
+            child, deleted := walker.walk(field.Interface(), childCursor)
+            setField(field, child, deleted)
+            continue
+        }
+        // slice items, deleted items are removed
+        items := reflect.MakeSlice(field.Type(), 0, field.Len())
+        for j := 0; j < field.Len(); j++ {
+            item := field.Index(j)
+            if walker.stopped || isNilValue(item) {
+                items = reflect.Append(items, item)
+                continue
+            }
+            itemCursor := &Cursor{ancestors: childCursor.ancestors, path: append(append([]interface{}{}, childCursor.path...), j)}
+            child, deleted := walker.walk(item.Interface(), itemCursor)
+            if deleted {
+                continue
+            }
+            items = reflect.Append(items, getAssignableValue(child, field.Type().Elem()))
+        }
+        if field.Len() > 0 {
+            field.Set(items)
+        }
+    }
+    if nodeValue.Kind() == reflect.Struct {
+        return structValue.Interface()
+    }
+    return node
+}
+
+func isNilValue(value reflect.Value) bool {
+    switch value.Kind() {
+    case reflect.Ptr, reflect.Interface, reflect.Slice:
+        return value.IsNil()
+    }
+    return false
+}
+
+// set replaced node into parent field, deleted node sets field to zero value
+func setField(field reflect.Value, node interface{}, deleted bool) {
+    if deleted {
+        field.Set(reflect.Zero(field.Type()))
+        return
+    }
+    field.Set(getAssignableValue(node, field.Type()))
+}
+
+// convert node to reflect value of target type, nil node is zero value
+func getAssignableValue(node interface{}, targetType reflect.Type) reflect.Value {
+    if node == nil {
+        return reflect.Zero(targetType)
+    }
+    value := reflect.ValueOf(node)
+    if !value.Type().AssignableTo(targetType) {
+        if value.Type().ConvertibleTo(targetType) {
+            return value.Convert(targetType)
+        }
+        panic("Visit(): cannot replace " + targetType.String() + " node with " + value.Type().String() + ".")
+    }
+    return value
+}
@@ -0,0 +1,327 @@
+// visitor_test.go
+
+package frontend
+
+import (
+    "fmt"
+    "reflect"
+    "testing"
+)
+
+// kinds recorded by visitor tests
+var visitTestKinds = []string{"Document", "OperationDefinition", "SelectionSet", "Field", "Alias", "Argument", "ListValue", "IntValue", "StringValue", "Directive"}
+
+func describeNode(node interface{}) string {
+    switch n := node.(type) {
+    case *Field:
+        return "Field " + n.Name.Value
+    case *Argument:
+        return "Argument " + n.Name.Value
+    case *Directive:
+        return "Directive " + n.Name.Value
+    case IntValue:
+        return fmt.Sprintf("IntValue %d", n.Value)
+    case StringValue:
+        return "StringValue " + n.Value
+    }
+    return GetNodeKind(node)
+}
+
+/**
+ * Visit tests
+ * enter and leave are called for recorded kinds after the event is recorded, events are "enter <node>"
+ * and "leave <node>" in walking order, printed is the Print() output of the visited document.
+ */
+var visitTests = []struct {
+    name    string
+    query   string
+    enter   VisitFunc
+    leave   VisitFunc
+    events  []string
+    printed string
+}{
+    {
+        name:  "order",
+        query: `{ a(x: 1) b }`,
+        events: []string{
+            "enter Document", "enter OperationDefinition", "enter SelectionSet",
+            "enter Field a", "enter Argument x", "enter IntValue 1", "leave IntValue 1", "leave Argument x", "leave Field a",
+            "enter Field b", "leave Field b",
+            "leave SelectionSet", "leave OperationDefinition", "leave Document",
+        },
+        printed: "{\n  a(x: 1)\n  b\n}",
+    },
+    {
+        name:  "skip children and leave",
+        query: `{ a(x: 1) b }`,
+        enter: func(cursor *Cursor) VisitAction {
+            if describeNode(cursor.Node()) == "Field a" {
+                return VisitSkip
+            }
+            return VisitContinue
+        },
+        events: []string{
+            "enter Document", "enter OperationDefinition", "enter SelectionSet",
+            "enter Field a",
+            "enter Field b", "leave Field b",
+            "leave SelectionSet", "leave OperationDefinition", "leave Document",
+        },
+        printed: "{\n  a(x: 1)\n  b\n}",
+    },
+    {
+        name:  "break in enter",
+        query: `{ a(x: 1) b }`,
+        enter: func(cursor *Cursor) VisitAction {
+            if cursor.Kind() == "Argument" {
+                return VisitBreak
+            }
+            return VisitContinue
+        },
+        events:  []string{"enter Document", "enter OperationDefinition", "enter SelectionSet", "enter Field a", "enter Argument x"},
+        printed: "{\n  a(x: 1)\n  b\n}",
+    },
+    {
+        name:  "break in leave",
+        query: `{ a(x: 1) b }`,
+        leave: func(cursor *Cursor) VisitAction {
+            if cursor.Kind() == "Argument" {
+                return VisitBreak
+            }
+            return VisitContinue
+        },
+        events: []string{
+            "enter Document", "enter OperationDefinition", "enter SelectionSet",
+            "enter Field a", "enter Argument x", "enter IntValue 1", "leave IntValue 1", "leave Argument x",
+        },
+        printed: "{\n  a(x: 1)\n  b\n}",
+    },
+    {
+        name:  "replace slice item in enter walks the new node",
+        query: `{ a(x: 1) b }`,
+        enter: func(cursor *Cursor) VisitAction {
+            if describeNode(cursor.Node()) == "Field a" {
+                cursor.Replace(&Field{Name: &Name{Value: "c"}, Arguments: []*Argument{{Name: &Name{Value: "y"}, Value: IntValue{Value: 2}}}})
+            }
+            return VisitContinue
+        },
+        events: []string{
+            "enter Document", "enter OperationDefinition", "enter SelectionSet",
+            "enter Field a", "enter Argument y", "enter IntValue 2", "leave IntValue 2", "leave Argument y", "leave Field c",
+            "enter Field b", "leave Field b",
+            "leave SelectionSet", "leave OperationDefinition", "leave Document",
+        },
+        printed: "{\n  c(y: 2)\n  b\n}",
+    },
+    {
+        name:  "replace slice item in leave",
+        query: `{ a b }`,
+        leave: func(cursor *Cursor) VisitAction {
+            if describeNode(cursor.Node()) == "Field b" {
+                cursor.Replace(&Field{Name: &Name{Value: "c"}})
+            }
+            return VisitContinue
+        },
+        printed: "{\n  a\n  c\n}",
+    },
+    {
+        name:  "replace single node field",
+        query: `{ a(x: 1) }`,
+        enter: func(cursor *Cursor) VisitAction {
+            if cursor.Kind() == "IntValue" {
+                cursor.Replace(StringValue{Value: "s"})
+            }
+            return VisitContinue
+        },
+        events: []string{
+            "enter Document", "enter OperationDefinition", "enter SelectionSet",
+            "enter Field a", "enter Argument x", "enter IntValue 1", "leave StringValue s", "leave Argument x", "leave Field a",
+            "leave SelectionSet", "leave OperationDefinition", "leave Document",
+        },
+        printed: "{\n  a(x: \"s\")\n}",
+    },
+    {
+        name:  "replace item of copied value node",
+        query: `{ a(x: [1, 2]) }`,
+        leave: func(cursor *Cursor) VisitAction {
+            if describeNode(cursor.Node()) == "IntValue 2" {
+                cursor.Replace(IntValue{Value: 3})
+            }
+            return VisitContinue
+        },
+        printed: "{\n  a(x: [1, 3])\n}",
+    },
+    {
+        name:  "delete slice item",
+        query: `{ a(x: 1) b }`,
+        enter: func(cursor *Cursor) VisitAction {
+            if describeNode(cursor.Node()) == "Field a" {
+                cursor.Delete()
+            }
+            return VisitContinue
+        },
+        events: []string{
+            "enter Document", "enter OperationDefinition", "enter SelectionSet",
+            "enter Field a",
+            "enter Field b", "leave Field b",
+            "leave SelectionSet", "leave OperationDefinition", "leave Document",
+        },
+        printed: "{\n  b\n}",
+    },
+    {
+        name:  "delete slice item in leave",
+        query: `{ a(x: 1, y: 2) @d }`,
+        leave: func(cursor *Cursor) VisitAction {
+            if describeNode(cursor.Node()) == "Argument x" || cursor.Kind() == "Directive" {
+                cursor.Delete()
+            }
+            return VisitContinue
+        },
+        printed: "{\n  a(y: 2)\n}",
+    },
+    {
+        name:  "delete item of copied value node",
+        query: `{ a(x: [1, 2]) }`,
+        enter: func(cursor *Cursor) VisitAction {
+            if describeNode(cursor.Node()) == "IntValue 1" {
+                cursor.Delete()
+            }
+            return VisitContinue
+        },
+        printed: "{\n  a(x: [2])\n}",
+    },
+    {
+        name:  "delete single node field",
+        query: `{ f: a }`,
+        enter: func(cursor *Cursor) VisitAction {
+            if cursor.Kind() == "Alias" {
+                cursor.Delete()
+            }
+            return VisitContinue
+        },
+        events: []string{
+            "enter Document", "enter OperationDefinition", "enter SelectionSet",
+            "enter Field a", "enter Alias", "leave Field a",
+            "leave SelectionSet", "leave OperationDefinition", "leave Document",
+        },
+        printed: "{\n  a\n}",
+    },
+}
+
+func TestVisit(t *testing.T) {
+    for _, test := range visitTests {
+        document, err := Compile(test.query)
+        if err != nil {
+            t.Errorf("%s: Compile() error: %v", test.name, err)
+            continue
+        }
+        var events []string
+        visitor := &Visitor{Enter: map[string]VisitFunc{}, Leave: map[string]VisitFunc{}}
+        enter, leave := test.enter, test.leave
+        for _, kind := range visitTestKinds {
+            visitor.Enter[kind] = func(cursor *Cursor) VisitAction {
+                events = append(events, "enter " + describeNode(cursor.Node()))
+                if enter != nil {
+                    return enter(cursor)
+                }
+                return VisitContinue
+            }
+            visitor.Leave[kind] = func(cursor *Cursor) VisitAction {
+                events = append(events, "leave " + describeNode(cursor.Node()))
+                if leave != nil {
+                    return leave(cursor)
+                }
+                return VisitContinue
+            }
+        }
+        root := Visit(document, visitor)
+        if root != document {
+            t.Errorf("%s: Visit() returned %v, want the document", test.name, root)
+        }
+        if test.events != nil && !reflect.DeepEqual(events, test.events) {
+            t.Errorf("%s: events =\n    %q\nwant\n    %q", test.name, events, test.events)
+        }
+        if printed := Print(document); printed != test.printed {
+            t.Errorf("%s: Print() =\n%s\nwant:\n%s", test.name, printed, test.printed)
+        }
+    }
+}
+
+func TestVisitReplaceRoot(t *testing.T) {
+    document, _ := Compile(`{ a }`)
+    replaced := &Document{}
+    root := Visit(document, &Visitor{Enter: map[string]VisitFunc{
+        "Document": func(cursor *Cursor) VisitAction {
+            cursor.Replace(replaced)
+            return VisitContinue
+        },
+    }})
+    if root != replaced {
+        t.Errorf("Visit() = %v, want replaced document", root)
+    }
+    root = Visit(document, &Visitor{Leave: map[string]VisitFunc{
+        "Document": func(cursor *Cursor) VisitAction {
+            cursor.Delete()
+            return VisitContinue
+        },
+    }})
+    if root != nil {
+        t.Errorf("Visit() = %v, want nil for deleted root", root)
+    }
+}
+
+func TestVisitInvalidReplace(t *testing.T) {
+    document, _ := Compile(`{ a }`)
+    defer func() {
+        want := "Visit(): cannot replace frontend.Selection node with *frontend.Name."
+        if recovered := recover(); recovered != want {
+            t.Errorf("Visit() panic = %v, want %q", recovered, want)
+        }
+    }()
+    Visit(document, &Visitor{Enter: map[string]VisitFunc{
+        "Field": func(cursor *Cursor) VisitAction {
+            cursor.Replace(&Name{Value: "b"})
+            return VisitContinue
+        },
+    }})
+    t.Errorf("Visit() did not panic")
+}
+
+func TestWalkPathAndAncestors(t *testing.T) {
+    document, _ := Compile(`query Q($a: Int = 1) { f: a(x: [2, $a]) }`)
+    type position struct {
+        node      string
+        key       interface{}
+        parent    string
+        path      []interface{}
+        ancestors []string
+    }
+    want := []position{
+        {"IntValue 1", "DefaultValue", "VariableDefinition",
+            []interface{}{"Definitions", 0, "VariableDefinitions", 0, "DefaultValue"},
+            []string{"Document", "OperationDefinition", "VariableDefinition"}},
+        {"IntValue 2", 0, "ListValue",
+            []interface{}{"Definitions", 0, "SelectionSet", "Selections", 0, "Arguments", 0, "Value", "Value", 0},
+            []string{"Document", "OperationDefinition", "SelectionSet", "Field", "Argument", "ListValue"}},
+        {"Variable", 1, "ListValue",
+            []interface{}{"Definitions", 0, "SelectionSet", "Selections", 0, "Arguments", 0, "Value", "Value", 1},
+            []string{"Document", "OperationDefinition", "SelectionSet", "Field", "Argument", "ListValue"}},
+    }
+    var got []position
+    Walk(document, func(cursor *Cursor) VisitAction {
+        if cursor.Kind() == "Document" && (cursor.Key() != nil || cursor.Parent() != nil || len(cursor.Path()) != 0) {
+            t.Errorf("root cursor has key %v, parent %v, path %v", cursor.Key(), cursor.Parent(), cursor.Path())
+        }
+        if cursor.Kind() != "IntValue" && cursor.Kind() != "Variable" {
+            return VisitContinue
+        }
+        var ancestors []string
+        for _, ancestor := range cursor.Ancestors() {
+            ancestors = append(ancestors, GetNodeKind(ancestor))
+        }
+        got = append(got, position{describeNode(cursor.Node()), cursor.Key(), GetNodeKind(cursor.Parent()), cursor.Path(), ancestors})
+        return VisitContinue
+    })
+    if !reflect.DeepEqual(got, want) {
+        t.Errorf("positions =\n    %v\nwant\n    %v", got, want)
+    }
+}